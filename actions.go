@@ -0,0 +1,81 @@
+package codegen
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/huandu/xstrings"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+// commonInputs are asked by every generator, through CmdAskProjectName,
+// CmdAskReview and CmdDownloadFiles.
+var commonInputs = []any{InputProjectName{}, InputReview{}, InputSourceDownloaded{}}
+
+// ActionID returns the stable identifier of the prompts answered with the given
+// input type, set as their `action_id`: the generator ID, followed by the type
+// name without its `Input` prefix, in snake case. Ex: `evm-events-calls.contract_address`
+// for the `InputContractAddress` of the `evm-events-calls` generator.
+//
+// Only the generator ID and the type name are used, so the identifier doesn't
+// change with the wording of the prompt, nor with the package of the type.
+func ActionID(generatorID string, inputType reflect.Type) string {
+	name := xstrings.ToSnakeCase(strings.TrimPrefix(inputType.Name(), "Input"))
+	if generatorID == "" {
+		return name
+	}
+	return generatorID + "." + name
+}
+
+// shortActionID returns the action ID without its generator ID.
+func shortActionID(actionID string) string {
+	if idx := strings.LastIndex(actionID, "."); idx != -1 {
+		return actionID[idx+1:]
+	}
+	return actionID
+}
+
+var inputEntryNames = map[reflect.Type]string{
+	reflect.TypeOf((*pbconvo.UserInput_TextInput)(nil)).Elem():       "text_input",
+	reflect.TypeOf((*pbconvo.UserInput_Selection)(nil)).Elem():       "selection",
+	reflect.TypeOf((*pbconvo.UserInput_Confirmation)(nil)).Elem():    "confirmation",
+	reflect.TypeOf((*pbconvo.UserInput_Upload)(nil)).Elem():          "file",
+	reflect.TypeOf((*pbconvo.UserInput_FormResponse)(nil)).Elem():    "form_response",
+	reflect.TypeOf((*pbconvo.UserInput_DownloadedFiles)(nil)).Elem(): "downloaded_files",
+}
+
+// inputEntryName returns the name of the `UserInput` entry embedded in the input
+// type, ex: `text_input`.
+func inputEntryName(inputType reflect.Type) string {
+	if inputType.Kind() != reflect.Struct {
+		return ""
+	}
+	for i := 0; i < inputType.NumField(); i++ {
+		field := inputType.Field(i)
+		if name, found := inputEntryNames[field.Type]; found && field.Anonymous {
+			return name
+		}
+	}
+	return ""
+}
+
+// Actions returns the catalog of the prompts the generator can ask, sorted by ID.
+func (h *ConversationHandler) Actions() []*pbconvo.DiscoveryResponse_Action {
+	seen := make(map[string]bool)
+	var out []*pbconvo.DiscoveryResponse_Action
+	for _, input := range append(append([]any{}, commonInputs...), h.Inputs...) {
+		inputType := reflect.TypeOf(input)
+		id := ActionID(h.ID, inputType)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, &pbconvo.DiscoveryResponse_Action{
+			Id:    id,
+			Input: inputEntryName(inputType),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id < out[j].Id })
+	return out
+}
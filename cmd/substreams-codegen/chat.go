@@ -0,0 +1,642 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	codegen "github.com/streamingfast/substreams-codegen"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+	"github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1/pbconvoconnect"
+	"golang.org/x/net/http2"
+)
+
+func chatE(cmd *cobra.Command, args []string) error {
+	endpoint := sflags.MustGetString(cmd, "endpoint")
+	generatorID := sflags.MustGetString(cmd, "generator")
+	statePath := sflags.MustGetString(cmd, "state")
+	resume := sflags.MustGetBool(cmd, "resume")
+	outputDir := sflags.MustGetString(cmd, "out")
+	apiKey := sflags.MustGetString(cmd, "api-key")
+	token := sflags.MustGetString(cmd, "token")
+
+	start := &pbconvo.UserInput_Start{
+		GeneratorId: generatorID,
+		Version:     codegen.ProtocolVersionReview,
+	}
+	if resume {
+		cnt, err := os.ReadFile(statePath)
+		if err != nil {
+			return fmt.Errorf("reading state file to resume from: %w", err)
+		}
+		var generatorFile codegen.GeneratorFile
+		if err := json.Unmarshal(cnt, &generatorFile); err != nil {
+			return fmt.Errorf("decoding state file %q: %w", statePath, err)
+		}
+		if start.GeneratorId == "" {
+			start.GeneratorId = generatorFile.Generator
+		}
+		// The state is saved indented, compacting it gives back the exact bytes
+		// the server signed.
+		savedState := &bytes.Buffer{}
+		if err := json.Compact(savedState, generatorFile.State); err != nil {
+			return fmt.Errorf("decoding state in %q: %w", statePath, err)
+		}
+		start.Hydrate = &pbconvo.UserInput_Hydrate{
+			SavedState: savedState.String(),
+			Signature:  generatorFile.Signature,
+		}
+	}
+	if start.GeneratorId == "" {
+		return fmt.Errorf("specify the generator to talk to with --generator")
+	}
+
+	client := pbconvoconnect.NewConversationServiceClient(newChatHTTPClient(endpoint), endpoint)
+	c := &chat{
+		generatorID: start.GeneratorId,
+		statePath:   statePath,
+		outputDir:   outputDir,
+		apiKey:      apiKey,
+		token:       token,
+		in:          bufio.NewReader(os.Stdin),
+	}
+
+	fmt.Print("Type /back at any prompt to undo your previous answer.\n\n")
+	for reconnects := 0; ; reconnects++ {
+		err := c.converse(cmd.Context(), client, start)
+		if err == nil || connect.CodeOf(err) != connect.CodeUnavailable || c.lastMsgID == 0 || reconnects >= maxChatReconnects {
+			return err
+		}
+
+		// Pick up where the connection dropped, the server re-emits the pending prompt
+		fmt.Printf("Connection lost (%s), reconnecting...\n\n", err)
+		start = &pbconvo.UserInput_Start{
+			GeneratorId: c.generatorID,
+			Version:     codegen.ProtocolVersionReview,
+			Hydrate: &pbconvo.UserInput_Hydrate{
+				SavedState: c.lastState,
+				Signature:  c.lastSignature,
+				LastMsgId:  c.lastMsgID,
+			},
+		}
+	}
+}
+
+const maxChatReconnects = 5
+
+func (c *chat) converse(ctx context.Context, client pbconvoconnect.ConversationServiceClient, start *pbconvo.UserInput_Start) error {
+	stream := client.Converse(ctx)
+	defer stream.CloseRequest()
+	if c.apiKey != "" {
+		stream.RequestHeader().Set("X-Api-Key", c.apiKey)
+	}
+	if c.token != "" {
+		stream.RequestHeader().Set("Authorization", "Bearer "+c.token)
+	}
+
+	if err := stream.Send(&pbconvo.UserInput{Entry: &pbconvo.UserInput_Start_{Start: start}}); err != nil {
+		return fmt.Errorf("starting conversation: %w", err)
+	}
+
+	for {
+		msg, err := stream.Receive()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("receiving from codegen server: %w", err)
+		}
+
+		c.lastMsgID = msg.MsgId
+		if msg.State != "" {
+			c.lastState = msg.State
+			c.lastSignature = msg.StateSignature
+		}
+		if err := c.saveState(msg.State, msg.StateSignature); err != nil {
+			return err
+		}
+
+		input, err := c.render(msg)
+		if err != nil {
+			return err
+		}
+		if input == nil {
+			continue
+		}
+
+		input.FromMsgId = msg.MsgId
+		input.FromActionId = msg.ActionId
+		if err := stream.Send(input); err != nil {
+			return fmt.Errorf("sending answer: %w", err)
+		}
+	}
+}
+
+// newChatHTTPClient returns a client able to do bidirectional streaming, which
+// requires HTTP/2, also over plain-text `http://` endpoints.
+func newChatHTTPClient(endpoint string) *http.Client {
+	if strings.HasPrefix(endpoint, "https://") {
+		return &http.Client{Transport: &http2.Transport{}}
+	}
+
+	return &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+type chat struct {
+	generatorID string
+	statePath   string
+	outputDir   string
+	apiKey      string
+	token       string
+
+	in *bufio.Reader
+
+	lastMsgID     uint32
+	lastState     string
+	lastSignature []byte
+}
+
+// saveState writes the state in the `generator.json` format after every step, so
+// the conversation can be resumed later with --resume.
+func (c *chat) saveState(state string, signature []byte) error {
+	if state == "" {
+		return nil
+	}
+
+	cnt, err := json.MarshalIndent(codegen.GeneratorFile{
+		Generator: c.generatorID,
+		State:     json.RawMessage(state),
+		Signature: signature,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding state: %w", err)
+	}
+
+	if err := os.WriteFile(c.statePath, cnt, 0644); err != nil {
+		return fmt.Errorf("saving state to %q: %w", c.statePath, err)
+	}
+	return nil
+}
+
+// render prints a SystemOutput and, when it asks for something, reads the answer
+// from the terminal.
+func (c *chat) render(msg *pbconvo.SystemOutput) (*pbconvo.UserInput, error) {
+	switch entry := msg.Entry.(type) {
+	case *pbconvo.SystemOutput_Message_:
+		if entry.Message.Style != "" {
+			fmt.Printf("[%s] ", entry.Message.Style)
+		}
+		fmt.Println(entry.Message.Markdown)
+		fmt.Println()
+
+	case *pbconvo.SystemOutput_ImageWithText_:
+		fmt.Printf("%s\n(image: %s)\n\n", entry.ImageWithText.Markdown, entry.ImageWithText.ImgUrl)
+
+	case *pbconvo.SystemOutput_Table_:
+		fmt.Printf("%s\n\n", entry.Table.Text())
+
+	case *pbconvo.SystemOutput_Code_:
+		if entry.Code.Title != "" {
+			fmt.Println(entry.Code.Title)
+		}
+		fmt.Printf("%s\n\n", strings.TrimSuffix(entry.Code.Content, "\n"))
+
+	case *pbconvo.SystemOutput_FileTree_:
+		fmt.Printf("%s\n\n", entry.FileTree.Text())
+
+	case *pbconvo.SystemOutput_Loading_:
+		if entry.Loading.Loading {
+			fmt.Printf("%s...\n", entry.Loading.Label)
+		}
+
+	case *pbconvo.SystemOutput_ListSelect_:
+		if entry.ListSelect.SelectMany {
+			values, labels, err := c.pickMany(entry.ListSelect)
+			if errors.Is(err, errGoBack) {
+				return goBackInput(), nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Selection_{
+				Selection: &pbconvo.UserInput_Selection{Labels: labels, Values: values},
+			}}, nil
+		}
+
+		value, label, err := c.pick(entry.ListSelect)
+		if errors.Is(err, errGoBack) {
+			return goBackInput(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Selection_{
+			Selection: &pbconvo.UserInput_Selection{Label: label, Value: value},
+		}}, nil
+
+	case *pbconvo.SystemOutput_TextInput_:
+		value, err := c.textInput(entry.TextInput)
+		if errors.Is(err, errGoBack) {
+			return goBackInput(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_TextInput_{
+			TextInput: &pbconvo.UserInput_TextInput{Value: value},
+		}}, nil
+
+	case *pbconvo.SystemOutput_Confirm_:
+		affirmative, err := c.confirm(entry.Confirm)
+		if errors.Is(err, errGoBack) {
+			return goBackInput(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Confirmation_{
+			Confirmation: &pbconvo.UserInput_Confirmation{Affirmative: affirmative},
+		}}, nil
+
+	case *pbconvo.SystemOutput_Upload_:
+		upload, err := c.upload(entry.Upload)
+		if errors.Is(err, errGoBack) {
+			return goBackInput(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_File{File: upload}}, nil
+
+	case *pbconvo.SystemOutput_Form_:
+		response, err := c.form(entry.Form)
+		if errors.Is(err, errGoBack) {
+			return goBackInput(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_FormResponse_{FormResponse: response}}, nil
+
+	case *pbconvo.SystemOutput_DownloadFiles_:
+		if err := c.download(entry.DownloadFiles); err != nil {
+			return nil, err
+		}
+
+	default:
+		fmt.Printf("(unsupported output %T)\n", entry)
+	}
+
+	return nil, nil
+}
+
+func (c *chat) pick(sel *pbconvo.SystemOutput_ListSelect) (value string, label string, err error) {
+	fmt.Println(sel.Instructions)
+	defaultIdx := -1
+	for i, v := range sel.Values {
+		marker := " "
+		if v == sel.DefaultValue {
+			marker = "*"
+			defaultIdx = i
+		}
+		fmt.Printf("  %s %d) %s\n", marker, i+1, labelAt(sel, i))
+	}
+
+	for {
+		line, err := c.prompt("Choice")
+		if err != nil {
+			return "", "", err
+		}
+
+		idx := -1
+		switch {
+		case line == "" && defaultIdx != -1:
+			idx = defaultIdx
+		case line != "":
+			if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(sel.Values) {
+				idx = n - 1
+			}
+			for i, v := range sel.Values {
+				if v == line {
+					idx = i
+				}
+			}
+		}
+		if idx == -1 {
+			fmt.Printf("Please pick a number between 1 and %d\n", len(sel.Values))
+			continue
+		}
+
+		fmt.Println()
+		return sel.Values[idx], labelAt(sel, idx), nil
+	}
+}
+
+func labelAt(sel *pbconvo.SystemOutput_ListSelect, idx int) string {
+	if idx < len(sel.Labels) {
+		return sel.Labels[idx]
+	}
+	return sel.Values[idx]
+}
+
+// pickMany asks for a comma-separated list of entry numbers, `all` or `none`.
+func (c *chat) pickMany(sel *pbconvo.SystemOutput_ListSelect) (values []string, labels []string, err error) {
+	fmt.Println(sel.Instructions)
+	for i, v := range sel.Values {
+		marker := " "
+		if slices.Contains(sel.DefaultValues, v) {
+			marker = "*"
+		}
+		fmt.Printf("  %s %d) %s\n", marker, i+1, labelAt(sel, i))
+	}
+
+	for {
+		line, err := c.prompt("Choices (ex: 1,3,4, all or none)")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var indexes []int
+		switch {
+		case line == "":
+			for i, v := range sel.Values {
+				if slices.Contains(sel.DefaultValues, v) {
+					indexes = append(indexes, i)
+				}
+			}
+		case strings.EqualFold(line, "all"):
+			for i := range sel.Values {
+				indexes = append(indexes, i)
+			}
+		case strings.EqualFold(line, "none"):
+		default:
+			for _, choice := range strings.Split(line, ",") {
+				n, err := strconv.Atoi(strings.TrimSpace(choice))
+				if err != nil || n < 1 || n > len(sel.Values) {
+					indexes = nil
+					break
+				}
+				indexes = append(indexes, n-1)
+			}
+			if indexes == nil {
+				fmt.Printf("Please pick numbers between 1 and %d, separated by commas\n", len(sel.Values))
+				continue
+			}
+		}
+
+		fmt.Println()
+		values, labels = []string{}, []string{}
+		for _, idx := range indexes {
+			values = append(values, sel.Values[idx])
+			labels = append(labels, labelAt(sel, idx))
+		}
+		return values, labels, nil
+	}
+}
+
+func (c *chat) textInput(input *pbconvo.SystemOutput_TextInput) (string, error) {
+	fmt.Println(input.Prompt)
+	if input.Description != "" {
+		fmt.Println(input.Description)
+	}
+
+	var validation *regexp.Regexp
+	if input.ValidationRegexp != "" {
+		re, err := regexp.Compile(input.ValidationRegexp)
+		if err != nil {
+			return "", fmt.Errorf("invalid validation regexp %q from server: %w", input.ValidationRegexp, err)
+		}
+		validation = re
+	}
+
+	label := "Value"
+	if input.DefaultValue != "" {
+		label = fmt.Sprintf("Value [%s]", input.DefaultValue)
+	}
+
+	for {
+		var value string
+		var err error
+		if input.MultiLine > 0 {
+			fmt.Println("(end with an empty line)")
+			value, err = c.readMultiLine()
+		} else {
+			value, err = c.prompt(label)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if value == "" {
+			value = input.DefaultValue
+		}
+
+		if validation != nil && !validation.MatchString(value) {
+			if input.ValidationErrorMessage != "" {
+				fmt.Println(input.ValidationErrorMessage)
+			} else {
+				fmt.Printf("Value must match %s\n", input.ValidationRegexp)
+			}
+			continue
+		}
+
+		fmt.Println()
+		return value, nil
+	}
+}
+
+func (c *chat) confirm(conf *pbconvo.SystemOutput_Confirm) (bool, error) {
+	fmt.Println(conf.Prompt)
+	if conf.Description != "" {
+		fmt.Println(conf.Description)
+	}
+
+	accept, decline := conf.AcceptButtonLabel, conf.DeclineButtonLabel
+	switch conf.DefaultButton {
+	case pbconvo.SystemOutput_Confirm_CONFIRM:
+		accept = "*" + accept
+	case pbconvo.SystemOutput_Confirm_DECLINE:
+		decline = "*" + decline
+	}
+
+	for {
+		line, err := c.prompt(fmt.Sprintf("[%s/%s]", accept, decline))
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case line == "" && conf.DefaultButton == pbconvo.SystemOutput_Confirm_CONFIRM:
+			return true, nil
+		case line == "" && conf.DefaultButton == pbconvo.SystemOutput_Confirm_DECLINE:
+			return false, nil
+		case strings.EqualFold(line, conf.AcceptButtonLabel), strings.EqualFold(line, "y"), strings.EqualFold(line, "yes"):
+			return true, nil
+		case strings.EqualFold(line, conf.DeclineButtonLabel), strings.EqualFold(line, "n"), strings.EqualFold(line, "no"):
+			return false, nil
+		}
+		fmt.Printf("Please answer %q or %q\n", conf.AcceptButtonLabel, conf.DeclineButtonLabel)
+	}
+}
+
+// form asks the fields of the form one after the other, and sends them all at once.
+func (c *chat) form(form *pbconvo.SystemOutput_Form) (*pbconvo.UserInput_FormResponse, error) {
+	fmt.Println(form.Prompt)
+	if form.Description != "" {
+		fmt.Println(form.Description)
+	}
+	fmt.Println()
+
+	response := &pbconvo.UserInput_FormResponse{}
+	for _, field := range form.Fields {
+		answer := &pbconvo.UserInput_FormResponse_Field{Name: field.Name}
+		switch entry := field.Entry.(type) {
+		case *pbconvo.SystemOutput_Form_Field_TextInput:
+			value, err := c.textInput(entry.TextInput)
+			if err != nil {
+				return nil, err
+			}
+			answer.Entry = &pbconvo.UserInput_FormResponse_Field_TextInput{TextInput: &pbconvo.UserInput_TextInput{Value: value}}
+
+		case *pbconvo.SystemOutput_Form_Field_ListSelect:
+			selection := &pbconvo.UserInput_Selection{}
+			var err error
+			if entry.ListSelect.SelectMany {
+				selection.Values, selection.Labels, err = c.pickMany(entry.ListSelect)
+			} else {
+				selection.Value, selection.Label, err = c.pick(entry.ListSelect)
+			}
+			if err != nil {
+				return nil, err
+			}
+			answer.Entry = &pbconvo.UserInput_FormResponse_Field_Selection{Selection: selection}
+
+		case *pbconvo.SystemOutput_Form_Field_Confirm:
+			affirmative, err := c.confirm(entry.Confirm)
+			if err != nil {
+				return nil, err
+			}
+			answer.Entry = &pbconvo.UserInput_FormResponse_Field_Confirmation{Confirmation: &pbconvo.UserInput_Confirmation{Affirmative: affirmative}}
+
+		default:
+			return nil, fmt.Errorf("unsupported form field %q of type %T", field.Name, entry)
+		}
+		response.Fields = append(response.Fields, answer)
+	}
+	return response, nil
+}
+
+// upload asks for the path of a local file, and sends its content.
+func (c *chat) upload(upload *pbconvo.SystemOutput_Upload) (*pbconvo.UserInput_Upload, error) {
+	fmt.Println(upload.Prompt)
+	if upload.Description != "" {
+		fmt.Println(upload.Description)
+	}
+
+	for {
+		path, err := c.prompt("File path")
+		if err != nil {
+			return nil, err
+		}
+		if path == "" {
+			continue
+		}
+
+		cnt, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("Cannot read %q: %s\n", path, err)
+			continue
+		}
+		if upload.MaxSize != 0 && uint64(len(cnt)) > upload.MaxSize {
+			fmt.Printf("File is %d bytes, it cannot be larger than %d bytes\n", len(cnt), upload.MaxSize)
+			continue
+		}
+
+		fmt.Println()
+		return &pbconvo.UserInput_Upload{
+			MimeType: mime.TypeByExtension(filepath.Ext(path)),
+			Filename: filepath.Base(path),
+			Content:  cnt,
+		}, nil
+	}
+}
+
+func (c *chat) download(download *pbconvo.SystemOutput_DownloadFiles) error {
+	files := make(map[string][]byte, len(download.Files))
+	for _, file := range download.Files {
+		files[file.Filename] = file.Content
+		if file.Description != "" {
+			fmt.Printf("  %s: %s\n", file.Filename, file.Description)
+		}
+	}
+
+	if err := codegen.WriteProjectFiles(c.outputDir, files); err != nil {
+		return fmt.Errorf("writing downloaded files: %w", err)
+	}
+
+	absDir, _ := filepath.Abs(c.outputDir)
+	fmt.Printf("Wrote %d files to %s\n\n", len(files), absDir)
+	return nil
+}
+
+// errGoBack is returned when the user types `/back` instead of an answer.
+var errGoBack = errors.New("go back")
+
+func goBackInput() *pbconvo.UserInput {
+	return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Back_{Back: &pbconvo.UserInput_Back{}}}
+}
+
+func (c *chat) prompt(label string) (string, error) {
+	fmt.Printf("%s > ", label)
+	line, err := c.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("reading answer: %w", err)
+	}
+	line = strings.TrimSpace(line)
+	if line == "/back" {
+		fmt.Println()
+		return "", errGoBack
+	}
+	return line, nil
+}
+
+func (c *chat) readMultiLine() (string, error) {
+	var lines []string
+	for {
+		line, err := c.in.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("reading answer: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" || errors.Is(err, io.EOF) {
+			if line != "" {
+				lines = append(lines, line)
+			}
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, line)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.uber.org/zap"
+)
+
+func generateE(cmd *cobra.Command, args []string) error {
+	generatorID := sflags.MustGetString(cmd, "generator")
+	statePath := sflags.MustGetString(cmd, "state")
+	outputDir := sflags.MustGetString(cmd, "out")
+
+	cnt, err := os.ReadFile(statePath)
+	if err != nil {
+		return fmt.Errorf("reading state file: %w", err)
+	}
+
+	var generatorFile codegen.GeneratorFile
+	if err := json.Unmarshal(cnt, &generatorFile); err != nil {
+		return fmt.Errorf("decoding state file %q: %w", statePath, err)
+	}
+
+	savedState := string(generatorFile.State)
+	if generatorFile.State == nil {
+		// Bare state, without the `generator.json` envelope
+		savedState = string(cnt)
+	}
+
+	if generatorID == "" {
+		generatorID = generatorFile.Generator
+	}
+	if generatorID == "" {
+		return fmt.Errorf("no generator ID found in %q, specify one with --generator", statePath)
+	}
+
+	zlog.Info("generating project from saved state",
+		zap.String("generator", generatorID),
+		zap.String("state", statePath),
+		zap.String("out", outputDir),
+	)
+
+	projectFiles, err := codegen.GenerateFromState(cmd.Context(), generatorID, savedState)
+	if err != nil {
+		return fmt.Errorf("generating %q from %q: %w", generatorID, statePath, err)
+	}
+
+	if err := codegen.WriteProjectFiles(outputDir, projectFiles); err != nil {
+		return fmt.Errorf("writing project files: %w", err)
+	}
+
+	fmt.Printf("Wrote %d files to %s\n", len(projectFiles), outputDir)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	_ "net/http/pprof"
 	"regexp"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/spf13/cobra"
@@ -12,6 +13,8 @@ import (
 	"github.com/streamingfast/cli/sflags"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/logging"
+	tracing "github.com/streamingfast/sf-tracing"
+	codegen "github.com/streamingfast/substreams-codegen"
 	"github.com/streamingfast/substreams-codegen/server"
 	"go.uber.org/zap"
 )
@@ -37,6 +40,47 @@ func main() {
 		ConfigureViper("CODEGEN"),
 		ConfigureVersion("dev"),
 
+		Command(generateE,
+			"generate",
+			"Generate a project from a saved generator.json state, without going through a conversation",
+			Flags(func(flags *pflag.FlagSet) {
+				flags.String("generator", "", "Generator ID to use, defaults to the 'generator' field of the state file")
+				flags.String("state", "generator.json", "Path to the saved state file")
+				flags.String("out", ".", "Directory where the generated project files are written")
+			}),
+		),
+
+		Command(runE,
+			"run",
+			"Run a full conversation non-interactively, answering every prompt from an answers file",
+			Flags(func(flags *pflag.FlagSet) {
+				flags.String("answers", "answers.yaml", "Path to the answers file (YAML or JSON), mapping each prompt's input type or action ID to a value")
+				flags.String("generator", "", "Generator ID to use, defaults to the 'generator' field of the answers file")
+				flags.String("out", ".", "Directory where the generated project files are written")
+				flags.String("files-root", ".", "Directory from which 'file://' paths and uploaded files in the answers (ex: contract ABIs) can be read, empty to disable")
+			}),
+		),
+
+		Command(chatE,
+			"chat",
+			"Talk to a running codegen server from the terminal, saving the state after every step so the conversation can be resumed",
+			Flags(func(flags *pflag.FlagSet) {
+				flags.String("endpoint", "http://localhost:9000", "Codegen server endpoint, http:// endpoints are reached over plain-text HTTP/2")
+				flags.String("generator", "", "Generator ID to talk to (ex: evm-events-calls), defaults to the 'generator' field of the state file when resuming")
+				flags.String("state", "generator.json", "Path where the conversation state is saved after every step")
+				flags.Bool("resume", false, "Resume the conversation from the state file instead of starting a new one")
+				flags.String("out", ".", "Directory where the downloaded project files are written")
+				flags.String("api-key", "", "API key to authenticate with, when the server requires it")
+				flags.String("token", "", "Bearer token (ex: a JWT) to authenticate with, when the server requires it")
+			}),
+		),
+
+		Command(replayE,
+			"replay <session.jsonl>",
+			"Replay a conversation saved in the session store, reporting where its outputs now differ from the recorded ones",
+			ExactArgs(1),
+		),
+
 		PersistentFlags(
 			func(flags *pflag.FlagSet) {
 				flags.Duration("delay-before-start", 0, "[OPERATOR] Amount of time to wait before starting any internal processes, can be used to perform to maintenance on the pod before actually letting it starts")
@@ -46,6 +90,15 @@ func main() {
 				flags.String("session-store-url", "", "Optional store to save session information (ex: file://./sessions or gs://bucket/sessions)")
 				flags.String("http-listen-addr", ":9000", "http listen address")
 				flags.String("cors-host-regex-allow", "^localhost", "Regex to allow CORS origin requests from, defaults to localhost only")
+				flags.String("state-signing-secret", "", "[OPERATOR] Secret used to sign the conversation state sent to clients (HMAC-SHA256), and to verify it when a conversation is hydrated. Signing is disabled when empty")
+				flags.String("local-files-root", "", "[OPERATOR] Directory from which 'file://' paths typed by users (ex: contract ABIs) can be read on the server, paths resolving outside of it are rejected. Disabled when empty, users upload their files instead")
+				flags.Duration("command-timeout", time.Minute, "[OPERATOR] Maximum duration of the commands run by a conversation without a limit of their own (ex: generating the project), after which they are abandoned and the conversation fails. Lookups on block explorers and RPC endpoints have their own limits, after which the user is asked to provide the information instead. Unlimited when 0")
+				flags.Int("max-concurrent-conversations", 0, "[OPERATOR] Maximum number of conversations in progress at once per client, unlimited when 0")
+				flags.Int("max-conversations-per-minute", 0, "[OPERATOR] Maximum number of conversations started per minute per client, unlimited when 0")
+				flags.Int("max-lookups-per-conversation", 0, "[OPERATOR] Maximum number of lookups (ex: fetching a contract ABI from a block explorer) per conversation, which is closed past it. Unlimited when 0")
+				flags.String("client-ip-header", "", "[OPERATOR] Header holding the IP of the clients, to which the limits apply (ex: 'X-Forwarded-For' behind a proxy), whose right-most entry, appended by the proxy, is used. The address of the connection is used when empty")
+				flags.String("auth-static-file", "", "[OPERATOR] File of the API keys and JWT secret clients must authenticate with (see server.StaticVerifier), in YAML or JSON format. Authentication is disabled when empty")
+				flags.String("unsigned-state-policy", "reject", "[OPERATOR] What to do with a hydrated state without a valid signature when signing is enabled: 'reject' refuses the conversation, 'mark' continues with the state flagged as unsigned, for which generators reset the fetched fields (ex: ABIs, initial blocks) and any field failing validation")
 			},
 		),
 		AfterAllHook(func(cmd *cobra.Command) {
@@ -65,6 +118,34 @@ func apiE(cmd *cobra.Command, args []string) error {
 	httpListenAddr := sflags.MustGetString(cmd, "http-listen-addr")
 	corsHostRegexAllow := sflags.MustGetString(cmd, "cors-host-regex-allow")
 	sessionStoreURL := sflags.MustGetString(cmd, "session-store-url")
+	stateSigningSecret := sflags.MustGetString(cmd, "state-signing-secret")
+	localFilesRoot := sflags.MustGetString(cmd, "local-files-root")
+	commandTimeout := sflags.MustGetDuration(cmd, "command-timeout")
+	limits := server.Limits{
+		ConcurrentConversations: sflags.MustGetInt(cmd, "max-concurrent-conversations"),
+		ConversationsPerMinute:  sflags.MustGetInt(cmd, "max-conversations-per-minute"),
+		LookupsPerConversation:  sflags.MustGetInt(cmd, "max-lookups-per-conversation"),
+		ClientIPHeader:          sflags.MustGetString(cmd, "client-ip-header"),
+	}
+
+	unsignedStatePolicy, err := server.ParseUnsignedStatePolicy(sflags.MustGetString(cmd, "unsigned-state-policy"))
+	if err != nil {
+		return err
+	}
+
+	var stateSigner *codegen.StateSigner
+	if stateSigningSecret != "" {
+		stateSigner = codegen.NewStateSigner(stateSigningSecret)
+	}
+
+	var verifier server.Verifier
+	if authStaticFile := sflags.MustGetString(cmd, "auth-static-file"); authStaticFile != "" {
+		staticVerifier, err := server.NewStaticVerifier(authStaticFile)
+		if err != nil {
+			return err
+		}
+		verifier = staticVerifier
+	}
 
 	sessionStore, err := dstore.NewStore(sessionStoreURL, "", "", false)
 	if err != nil {
@@ -75,6 +156,12 @@ func apiE(cmd *cobra.Command, args []string) error {
 		zap.String("http_listen_addr", httpListenAddr),
 		zap.String("cors_host_regex_allow", corsHostRegexAllow),
 		zap.String("session_store_url", sessionStoreURL),
+		zap.Bool("state_signing", stateSigner != nil),
+		zap.String("unsigned_state_policy", string(unsignedStatePolicy)),
+		zap.String("local_files_root", localFilesRoot),
+		zap.Duration("command_timeout", commandTimeout),
+		zap.Reflect("limits", limits),
+		zap.Bool("auth", verifier != nil),
 	)
 
 	var cors *regexp.Regexp
@@ -86,10 +173,22 @@ func apiE(cmd *cobra.Command, args []string) error {
 		cors = hostRegex
 	}
 
+	codegen.RegisterMetrics()
+
+	if err := tracing.SetupOpenTelemetry(cmd.Context(), "substreams-codegen"); err != nil {
+		return fmt.Errorf("failed to setup tracing: %w", err)
+	}
+
 	server := server.New(
 		httpListenAddr,
 		cors,
 		sessionStore,
+		stateSigner,
+		unsignedStatePolicy,
+		localFilesRoot,
+		commandTimeout,
+		limits,
+		verifier,
 		zlog)
 
 	app.SuperviseAndStart(server)
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.uber.org/zap"
+)
+
+func replayE(cmd *cobra.Command, args []string) error {
+	sessionPath := args[0]
+	commandTimeout := sflags.MustGetDuration(cmd, "command-timeout")
+
+	cnt, err := os.ReadFile(sessionPath)
+	if err != nil {
+		return fmt.Errorf("reading session file: %w", err)
+	}
+
+	session, err := codegen.ParseSession(cnt)
+	if err != nil {
+		return fmt.Errorf("decoding session file %q: %w", sessionPath, err)
+	}
+
+	zlog.Info("replaying session",
+		zap.String("generator", session.Generator),
+		zap.String("session", sessionPath),
+		zap.String("session_id", session.ID),
+		zap.Int("records", len(session.Records)),
+	)
+
+	diff, err := codegen.ReplaySession(cmd.Context(), session, commandTimeout)
+	if err != nil {
+		return fmt.Errorf("replaying %q: %w", sessionPath, err)
+	}
+	if diff != nil {
+		fmt.Print(diff.String())
+		return fmt.Errorf("replayed conversation differs from %q after input %d", sessionPath, diff.Step)
+	}
+
+	fmt.Printf("Replayed session %s of %s, no difference\n", session.ID, session.Generator)
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli/sflags"
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.uber.org/zap"
+)
+
+func runE(cmd *cobra.Command, args []string) error {
+	answersPath := sflags.MustGetString(cmd, "answers")
+	generatorID := sflags.MustGetString(cmd, "generator")
+	outputDir := sflags.MustGetString(cmd, "out")
+	localFilesRoot := sflags.MustGetString(cmd, "files-root")
+
+	answers, err := codegen.LoadAnswers(answersPath)
+	if err != nil {
+		return err
+	}
+	if generatorID != "" {
+		answers.Generator = generatorID
+	}
+	if answers.Generator == "" {
+		return fmt.Errorf("no generator ID found in %q, specify one with --generator", answersPath)
+	}
+	answers.LocalFilesRoot = localFilesRoot
+
+	zlog.Info("running scripted conversation",
+		zap.String("generator", answers.Generator),
+		zap.String("answers", answersPath),
+		zap.String("out", outputDir),
+	)
+
+	projectFiles, err := codegen.RunScripted(cmd.Context(), answers, func(event string) {
+		fmt.Println(event)
+	})
+	if err != nil {
+		return fmt.Errorf("running %q conversation from %q: %w", answers.Generator, answersPath, err)
+	}
+
+	if err := codegen.WriteProjectFiles(outputDir, projectFiles); err != nil {
+		return fmt.Errorf("writing project files: %w", err)
+	}
+
+	fmt.Printf("Wrote %d files to %s\n", len(projectFiles), outputDir)
+	return nil
+}
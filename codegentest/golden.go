@@ -0,0 +1,31 @@
+// Package codegentest holds the helpers shared by the tests of the generators.
+package codegentest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "write the golden files with the current outputs instead of comparing them")
+
+// AssertGolden compares `got` to the content of the golden file at `path`. When
+// the tests are run with `-update`, the file is written with `got` instead, to be
+// reviewed and committed along with the change of behavior.
+func AssertGolden(t testing.TB, path string, got string) {
+	t.Helper()
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "reading golden file, run the tests with -update to create it")
+	assert.Equal(t, string(expected), got, "output differs from golden file %s, run the tests with -update to update it", path)
+}
@@ -1,8 +1,13 @@
 package codegen
 
 import (
+	"context"
+	"encoding/json"
 	"maps"
+	"reflect"
+	"regexp"
 	"slices"
+	"time"
 
 	"github.com/streamingfast/substreams-codegen/loop"
 )
@@ -11,37 +16,163 @@ type Conversation[X any] struct {
 	State X
 
 	factory *MsgWrapFactory
+
+	// snapshots taken before each input is applied
+	snapshots []snapshot
+	// reviewed is set once the user accepted the review step
+	reviewed bool
+}
+
+// snapshot is what going back restores: the serialized state, or its copy when
+// it can clone itself, and whether it was reviewed.
+type snapshot struct {
+	state    []byte
+	clone    any
+	reviewed bool
+}
+
+// cloner is implemented by states holding more than what they serialize (ex:
+// what was fetched in this session, links between their parts). Their snapshots
+// are deep copies restored as is, instead of serialized states.
+type cloner[X any] interface {
+	Clone() X
 }
 
 func (c *Conversation[X]) SetFactory(f *MsgWrapFactory) {
 	c.factory = f
 }
 
+// Identity returns who the user authenticated as, nil when the server does not
+// require authentication. The WithContext commands also find it in their
+// context, through IdentityFromContext.
+func (c *Conversation[X]) Identity() *Identity {
+	return c.factory.identity
+}
+
 func (c *Conversation[X]) GetState() any {
 	return c.State
 }
 
+// PushSnapshot saves the current state, before an input is applied to it.
+func (c *Conversation[X]) PushSnapshot() {
+	if state, ok := any(c.State).(cloner[X]); ok {
+		c.snapshots = append(c.snapshots, snapshot{clone: state.Clone(), reviewed: c.reviewed})
+		return
+	}
+	cnt, err := json.Marshal(c.State)
+	if err != nil {
+		panic(err)
+	}
+	c.snapshots = append(c.snapshots, snapshot{state: cnt, reviewed: c.reviewed})
+}
+
+// PopSnapshot restores the state saved before the last input, undoing exactly
+// that input, even when it left the state unchanged. It returns false when there
+// is nothing to go back to.
+func (c *Conversation[X]) PopSnapshot() bool {
+	if len(c.snapshots) == 0 {
+		return false
+	}
+	last := c.snapshots[len(c.snapshots)-1]
+	c.snapshots = c.snapshots[:len(c.snapshots)-1]
+
+	// Restored in place when the state is a pointer, for the pointers to it to
+	// stay valid. Without a clone, the state is restored exactly like a hydrated
+	// one: fields that are not serialized are computed again by NextStep().
+	v := reflect.ValueOf(c.State)
+	inPlace := v.Kind() == reflect.Pointer && !v.IsNil()
+	switch {
+	case last.clone != nil && inPlace:
+		v.Elem().Set(reflect.ValueOf(last.clone).Elem())
+	case last.clone != nil:
+		c.State = last.clone.(X)
+	case inPlace:
+		v.Elem().SetZero()
+		if err := json.Unmarshal(last.state, c.State); err != nil {
+			panic(err)
+		}
+	default:
+		var zero X
+		c.State = zero
+		if err := json.Unmarshal(last.state, &c.State); err != nil {
+			panic(err)
+		}
+	}
+	c.reviewed = last.reviewed
+	return true
+}
+
 func (c *Conversation[X]) Msg() *MsgWrap { return c.factory.NewMsg(c.State) }
 
 func (c *Conversation[X]) Action(element any) *MsgWrap {
 	return c.factory.NewInput(element, c.State)
 }
 
-func (c *Conversation[X]) CmdGenerate(f func() ReturnGenerate) loop.Cmd {
+func (c *Conversation[X]) CmdGenerate(f func(ctx context.Context) ReturnGenerate) loop.Cmd {
 	return loop.Seq(
 		c.Msg().Message("Generating Substreams module source code...").Cmd(),
-		func() loop.Msg {
-			return f()
-		},
+		loop.WithContext(func(ctx context.Context) loop.Msg {
+			begin := time.Now()
+			res := f(ctx)
+			GenerationDuration.WithLabelValues(c.factory.generatorID).Observe(time.Since(begin).Seconds())
+			if res.Err == nil {
+				size := 0
+				for _, content := range res.ProjectFiles {
+					size += len(content)
+				}
+				GenerationSize.WithLabelValues(c.factory.generatorID).Observe(float64(size))
+			}
+			return res
+		}),
+	)
+}
+
+// CmdGoBack undoes the last answer: the results of commands still in flight for
+// the abandoned step are dropped, the state is restored to its previous snapshot
+// and the conversation resumes from there.
+func CmdGoBack(conv Converser, factory *MsgWrapFactory) loop.Cmd {
+	factory.DropInFlight()
+
+	text := "Ok, let's go back."
+	if !conv.PopSnapshot() {
+		text = "There is nothing to go back to."
+	}
+	return loop.Seq(
+		factory.NewMsg(conv.GetState()).Message(text).Cmd(),
+		conv.NextStep(),
 	)
 }
 
+// CmdIncoming handles an input decoded by DecodeInput, the same way whatever the
+// transport: going back, asking the prompt again when the input is invalid, or
+// passing the typed input to the conversation once its state is saved to go back
+// to.
+func CmdIncoming(conv Converser, factory *MsgWrapFactory, msg IncomingMessage) loop.Cmd {
+	switch incoming := msg.Msg.(type) {
+	case MsgGoBack:
+		return CmdGoBack(conv, factory)
+	case MsgInvalidInput:
+		return CmdInvalidInput(factory, incoming)
+	}
+	conv.PushSnapshot()
+	return func() loop.Msg { return msg.Msg }
+}
+
+// projectNameRegexp validates the project names entered in CmdAskProjectName.
+var projectNameRegexp = regexp.MustCompile("^([a-z][a-z0-9_]{0,63})$")
+
+// ValidProjectName reports whether the project name would be accepted when
+// entered in CmdAskProjectName, ex: to check the one of a hydrated state.
+func ValidProjectName(name string) bool {
+	return projectNameRegexp.MatchString(name)
+}
+
 func (c *Conversation[X]) CmdAskProjectName() loop.Cmd {
 	return c.Action(InputProjectName{}).
 		TextInput("Please enter the project name", "Submit").
 		Description("Identifier with only lowercase letters, numbers and underscores, up to 64 characters.").
 		DefaultValue("my_project").
-		Validation("^([a-z][a-z0-9_]{0,63})$", "The project name must be a valid identifier with only lowercase letters, numbers and underscores, up to 64 characters.").
+		Validation(projectNameRegexp.String(), "The project name must be a valid identifier with only lowercase letters, numbers and underscores, up to 64 characters.").
 		Cmd()
 }
 
@@ -65,6 +196,7 @@ func (c *Conversation[X]) CmdDownloadFiles(msg ReturnGenerate) loop.Cmd {
 
 	return loop.Seq(
 		downloadCmd.Cmd(),
+		c.Msg().FileTree("Generated files", msg.ProjectFiles).Cmd(),
 		c.Msg().Messagef(`Your Substreams project is ready! Start streaming with:
 
 `+"```"+`bash
@@ -0,0 +1,293 @@
+package codegen
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+// driverMaxMessages bounds the messages processed before the conversation asks
+// something or ends, for conversations that would loop forever.
+const driverMaxMessages = 10000
+
+var errDriverLoop = fmt.Errorf("nothing asked after %d messages", driverMaxMessages)
+
+// Driver runs a conversation synchronously and deterministically, in-process:
+// it is the transport of tests, replays, and scripted or headless generation.
+// Commands run one at a time, in the order they are returned, those of a
+// `loop.Batch` included. The conversation stops at each prompt, until it is
+// answered with Answer() or AnswerInput().
+//
+// Commands taking a context are given a cancelled one, so that nothing is
+// fetched from the network: lookups fail, unless the message they are started
+// from is stubbed with Stub(), or a context is set with SetContext().
+type Driver struct {
+	Conversation Converser
+	Factory      *MsgWrapFactory
+
+	stubs        map[reflect.Type]func(msg loop.Msg) loop.Msg
+	ctx          context.Context
+	onEvent      func(event string)
+	begin        time.Time
+	queue        []loop.Cmd
+	prompt       *pbconvo.SystemOutput
+	outputs      []*pbconvo.SystemOutput
+	transcript   []string
+	projectFiles map[string][]byte
+	generated    *ReturnGenerate
+	done         bool
+	err          error
+}
+
+// NewDriver creates a driver for a new conversation of the registered generator,
+// as if started by a client of the given protocol version.
+func NewDriver(generatorID string, clientVersion uint32) (*Driver, error) {
+	handler := Registry[generatorID]
+	if handler == nil {
+		return nil, fmt.Errorf("no conversation handler found for generator ID %q", generatorID)
+	}
+
+	factory := NewMsgWrapFactory(nil)
+	factory.SetGeneratorID(generatorID)
+	factory.SetClientVersion(clientVersion)
+	conversation := handler.Factory()
+	conversation.SetFactory(factory)
+
+	return &Driver{
+		Conversation: conversation,
+		Factory:      factory,
+		stubs:        make(map[reflect.Type]func(msg loop.Msg) loop.Msg),
+	}, nil
+}
+
+// Stub replaces the handling of the messages of the same type as `trigger`,
+// usually the ones starting a lookup like `FetchContractABI{}`: instead of
+// `Update()`, the stub is called and the message it returns, usually the result
+// of the lookup, is processed in its place.
+func (d *Driver) Stub(trigger loop.Msg, stub func(msg loop.Msg) loop.Msg) {
+	d.stubs[reflect.TypeOf(trigger)] = stub
+}
+
+// SetContext sets the context given to the commands taking one, bounded by the
+// command timeout of the factory if any. Lookups then really happen, for
+// commands that are not stubbed.
+func (d *Driver) SetContext(ctx context.Context) {
+	d.ctx = ctx
+}
+
+// OnEvent sets a function receiving every output and answer of the conversation,
+// rendered like in the transcript but with the seconds elapsed since its start.
+func (d *Driver) OnEvent(onEvent func(event string)) {
+	d.onEvent = onEvent
+}
+
+// Start starts the conversation, hydrated with the saved state if not empty,
+// and runs it until it asks something or ends.
+func (d *Driver) Start(savedState string) error {
+	start := &pbconvo.UserInput_Start{
+		GeneratorId: d.Factory.generatorID,
+		Version:     d.Factory.clientVersion,
+	}
+	if savedState != "" {
+		start.Hydrate = &pbconvo.UserInput_Hydrate{SavedState: savedState}
+	}
+	return d.StartWith(start, false)
+}
+
+// StartWith starts the conversation with the given start input, like the one
+// received by the server, and runs it until it asks something or ends.
+func (d *Driver) StartWith(start *pbconvo.UserInput_Start, unsigned bool) error {
+	d.begin = time.Now()
+	msg := NewMsgStart(start, unsigned)
+	d.record(msg.Humanize)
+
+	d.queue = append(d.queue, func() loop.Msg { return NewMsgStart(start, unsigned) })
+	return d.run()
+}
+
+// Answer answers the pending prompts, one value each, written as in the answers
+// files of RunScripted (ex: `yes` for a confirmation, comma-separated values for
+// a multi-select list), and runs the conversation until it asks something again.
+func (d *Driver) Answer(values ...string) error {
+	for _, value := range values {
+		if d.prompt == nil {
+			return fmt.Errorf("no prompt to answer with %q", value)
+		}
+		req, err := inputFromValue(d.prompt, value)
+		if err != nil {
+			return err
+		}
+		if err := d.AnswerInput(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AnswerInput answers the pending prompt, like a client would, and runs the
+// conversation until it asks something again.
+func (d *Driver) AnswerInput(req *pbconvo.UserInput) error {
+	if d.done {
+		return fmt.Errorf("the conversation has ended: %w", d.err)
+	}
+	if d.prompt == nil {
+		return fmt.Errorf("no prompt to answer")
+	}
+
+	incoming, err := d.Factory.DecodeInput(req)
+	if err != nil {
+		return fmt.Errorf("decoding the answer to %q: %w", promptText(d.prompt), err)
+	}
+	d.prompt = nil
+	d.transcript = append(d.transcript, "")
+	d.record(incoming.Humanize)
+
+	d.queue = append(d.queue, CmdIncoming(d.Conversation, d.Factory, incoming))
+	return d.run()
+}
+
+// Prompt returns the prompt waiting for an answer, nil if none.
+func (d *Driver) Prompt() *pbconvo.SystemOutput {
+	return d.prompt
+}
+
+// Done reports whether the conversation has ended, with the error it ended with.
+func (d *Driver) Done() (bool, error) {
+	return d.done, d.err
+}
+
+// Outputs returns the outputs sent by the conversation so far.
+func (d *Driver) Outputs() []*pbconvo.SystemOutput {
+	return d.outputs
+}
+
+// ProjectFiles returns the files sent for download, nil until then.
+func (d *Driver) ProjectFiles() map[string][]byte {
+	return d.projectFiles
+}
+
+// Transcript returns the outputs of the conversation and the answers it got,
+// rendered by their Humanize() methods, as in the session logs.
+func (d *Driver) Transcript() string {
+	return strings.Join(d.transcript, "\n") + "\n"
+}
+
+// run processes the queued commands until there are none left, which happens
+// when the conversation waits for an answer or has ended.
+func (d *Driver) run() error {
+	for processed := 0; len(d.queue) != 0; processed++ {
+		if processed == driverMaxMessages {
+			return d.end(errDriverLoop)
+		}
+
+		cmd := d.queue[0]
+		d.queue = d.queue[1:]
+		if cmd == nil {
+			continue
+		}
+
+		msg, err := d.safe(cmd)
+		if err != nil {
+			return d.end(err)
+		}
+
+		switch msg := msg.(type) {
+		case nil:
+		case loop.SeqMsg:
+			d.queue = append(append([]loop.Cmd{}, msg...), d.queue...)
+		case loop.BatchMsg:
+			d.queue = append(append([]loop.Cmd{}, msg...), d.queue...)
+		case loop.WithContextMsg:
+			d.queue = append([]loop.Cmd{d.withContext(msg)}, d.queue...)
+		case loop.QuitMsg:
+			return d.end(msg.Err())
+		case *pbconvo.SystemOutput:
+			d.outputs = append(d.outputs, msg)
+			d.record(msg.Humanize)
+			if download := msg.GetDownloadFiles(); download != nil {
+				d.projectFiles = make(map[string][]byte, len(download.Files))
+				for _, file := range download.Files {
+					d.projectFiles[file.Filename] = file.Content
+				}
+				continue
+			}
+			if !msg.IsPrompt() {
+				continue
+			}
+			if d.prompt != nil {
+				return d.end(fmt.Errorf("prompt %q sent while %q is still unanswered", promptText(msg), promptText(d.prompt)))
+			}
+			d.prompt = msg
+		default:
+			if generated, ok := msg.(ReturnGenerate); ok {
+				d.generated = &generated
+			}
+			if stub := d.stubs[reflect.TypeOf(msg)]; stub != nil {
+				d.queue = append([]loop.Cmd{func() loop.Msg { return stub(msg) }}, d.queue...)
+				continue
+			}
+			d.queue = append([]loop.Cmd{func() loop.Msg {
+				if cmd := d.Conversation.Update(msg); cmd != nil {
+					return cmd()
+				}
+				return nil
+			}}, d.queue...)
+		}
+	}
+	return nil
+}
+
+// record adds an output or answer to the transcript, rendered by humanize.
+func (d *Driver) record(humanize func(seconds int) string) {
+	d.transcript = append(d.transcript, humanize(0))
+	if d.onEvent != nil {
+		d.onEvent(humanize(int(time.Since(d.begin).Seconds())))
+	}
+}
+
+// withContext gives the command the context set with SetContext(), or a
+// cancelled one.
+func (d *Driver) withContext(cmd loop.WithContextMsg) loop.Cmd {
+	return func() loop.Msg {
+		if d.ctx == nil {
+			cancelled, cancel := context.WithCancel(context.Background())
+			cancel()
+			return cmd.Run(cancelled)
+		}
+
+		ctx, cancel := context.WithCancel(d.ctx)
+		defer cancel()
+		if timeout := cmp.Or(cmd.Timeout, d.Factory.commandTimeout); timeout != 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return cmd.Run(ctx)
+	}
+}
+
+// safe runs the command, turning a panic into a *loop.PanicError.
+func (d *Driver) safe(cmd loop.Cmd) (msg loop.Msg, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &loop.PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return cmd(), nil
+}
+
+func (d *Driver) end(err error) error {
+	d.done = true
+	d.err = err
+	d.queue = nil
+	if err != nil {
+		d.transcript = append(d.transcript, fmt.Sprintf("ERROR %s", err))
+	}
+	return err
+}
@@ -94,6 +94,7 @@ func (a *ABI) BuildEventModels() (out []codegenEvent, err error) {
 			eventID := hex.EncodeToString(event.LogID())
 
 			codegenEvent := codegenEvent{
+				Signature: event.Signature(),
 				Rust: &rustEventModel{
 					ABIStructName:                             rustGeneratedStructName,
 					ProtoMessageName:                          rustGeneratedStructName,
@@ -164,6 +165,7 @@ func (a *ABI) BuildCallModels() (out []codegenCall, err error) {
 			protoMessageName := textcase.PascalCase(xstrings.ToSnakeCase(rustABIStructName) + "Call")
 
 			codegenCall := codegenCall{
+				Signature: call.Signature(),
 				Rust: &rustCallModel{
 					ABIStructName:                             rustGeneratedStructName,
 					ProtoMessageName:                          protoMessageName,
@@ -194,15 +196,23 @@ func (a *ABI) BuildCallModels() (out []codegenCall, err error) {
 }
 
 type codegenEvent struct {
-	Rust  *rustEventModel
-	Proto *protoEventModel
+	Signature string
+	Rust      *rustEventModel
+	Proto     *protoEventModel
 }
 
+// ID identifies the event in the selection of the contract's events.
+func (e codegenEvent) ID() string { return e.Rust.ABIStructName }
+
 type codegenCall struct {
-	Rust  *rustCallModel
-	Proto *protoCallModel
+	Signature string
+	Rust      *rustCallModel
+	Proto     *protoCallModel
 }
 
+// ID identifies the call in the selection of the contract's calls.
+func (c codegenCall) ID() string { return c.Rust.ABIStructName }
+
 type rustEventModel struct {
 	ABIStructName                             string
 	ProtoMessageName                          string
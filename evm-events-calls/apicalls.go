@@ -7,13 +7,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/streamingfast/dhttp"
 	"github.com/streamingfast/eth-go"
+	codegen "github.com/streamingfast/substreams-codegen"
 	"github.com/tidwall/gjson"
 )
 
@@ -29,26 +29,38 @@ func getContractABIFollowingProxy(ctx context.Context, contractAddress string, c
 	}
 
 	if chain.ApiEndpointDirect {
-		abi, abiContent, err := getContractABIDirect(ctx, contractAddress, chain.ApiEndpoint)
+		lookupCtx, done := codegen.StartLookup(ctx, chain.ID, "abi")
+		abi, abiContent, err := getContractABIDirect(lookupCtx, contractAddress, chain.ApiEndpoint)
+		done(err)
 		if err != nil {
 			return nil, err
 		}
 		return &ABI{abi, abiContent}, nil
 	}
-	abi, abiContent, wait, err := getContractABI(ctx, contractAddress, chain.ApiEndpoint, os.Getenv(chain.APIKeyEnvVar))
+	lookupCtx, done := codegen.StartLookup(ctx, chain.ID, "abi")
+	abi, abiContent, wait, err := getContractABI(lookupCtx, contractAddress, chain.ApiEndpoint, codegen.Getenv(ctx, chain.APIKeyEnvVar))
+	done(err)
 	if err != nil {
 		return nil, err
 	}
 
-	<-wait.C
-	implementationAddress, wait, err := getProxyContractImplementation(ctx, contractAddress, chain.ApiEndpoint, os.Getenv(chain.APIKeyEnvVar))
+	if err := waitForNextCall(ctx, wait); err != nil {
+		return nil, err
+	}
+	lookupCtx, done = codegen.StartLookup(ctx, chain.ID, "proxy")
+	implementationAddress, wait, err := getProxyContractImplementation(lookupCtx, contractAddress, chain.ApiEndpoint, codegen.Getenv(ctx, chain.APIKeyEnvVar))
+	done(err)
 	if err != nil {
 		return nil, err
 	}
-	<-wait.C
+	if err := waitForNextCall(ctx, wait); err != nil {
+		return nil, err
+	}
 
 	if implementationAddress != "" {
-		implementationABI, implementationABIContent, wait, err := getContractABI(ctx, implementationAddress, chain.ApiEndpoint, os.Getenv(chain.APIKeyEnvVar))
+		lookupCtx, done := codegen.StartLookup(ctx, chain.ID, "abi")
+		implementationABI, implementationABIContent, wait, err := getContractABI(lookupCtx, implementationAddress, chain.ApiEndpoint, codegen.Getenv(ctx, chain.APIKeyEnvVar))
+		done(err)
 		if err != nil {
 			return nil, err
 		}
@@ -80,7 +92,9 @@ func getContractABIFollowingProxy(ctx context.Context, contractAddress string, c
 		abiContent = string(content)
 
 		fmt.Printf("Fetched contract ABI for Implementation %s of Proxy %s\n", implementationAddress, contractAddress)
-		<-wait.C
+		if err := waitForNextCall(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
 
 	return &ABI{abi, abiContent}, nil
@@ -215,6 +229,18 @@ func timerUntilNextCall(msg string) *time.Timer {
 	return time.NewTimer(time.Millisecond * 400)
 }
 
+// waitForNextCall waits for the timer returned by timerUntilNextCall, unless the
+// context is done first.
+func waitForNextCall(ctx context.Context, timer *time.Timer) error {
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err()
+	}
+}
+
 // // Deprecated: use getContractABIFollowingProxy at the right place instead.
 // func getAndSetContractABIs(ctx context.Context, contracts []*Contract, chain *ChainConfig) ([]*Contract, error) {
 // 	for _, contract := range contracts {
@@ -234,14 +260,16 @@ func timerUntilNextCall(msg string) *time.Timer {
 // }
 
 // This is the NEW version, used by the new convo model.
-func getContractInitialBlock(ctx context.Context, chain *ChainConfig, contractAddress string) (uint64, error) {
+func getContractInitialBlock(ctx context.Context, chain *ChainConfig, contractAddress string) (blockNum uint64, err error) {
 	if initBlock, found := chain.initialBlockCache[contractAddress]; found {
 		// For testing purposes, when populating on-disk ABIs with setTestInitialBlock()
 		return initBlock, nil
 	}
+	ctx, done := codegen.StartLookup(ctx, chain.ID, "initial_block")
+	defer func() { done(err) }()
 
 	apiKey := ""
-	if key := os.Getenv(chain.APIKeyEnvVar); key != "" {
+	if key := codegen.Getenv(ctx, chain.APIKeyEnvVar); key != "" {
 		apiKey = fmt.Sprintf("&apiKey=%s", key)
 	}
 	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api?module=account&action=txlist&address=%s&page=1&offset=1&sort=asc%s", chain.ApiEndpoint, contractAddress, apiKey), nil)
@@ -272,7 +300,7 @@ func getContractInitialBlock(ctx context.Context, chain *ChainConfig, contractAd
 		return chain.FirstStreamableBlock, fmt.Errorf("empty result from response %v", response)
 	}
 
-	blockNum, err := strconv.ParseUint(response.Result[0].BlockNumber, 10, 64)
+	blockNum, err = strconv.ParseUint(response.Result[0].BlockNumber, 10, 64)
 	if err != nil {
 		return chain.FirstStreamableBlock, fmt.Errorf("parsing block number: %w", err)
 	}
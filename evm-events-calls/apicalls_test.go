@@ -0,0 +1,104 @@
+package evm_events_calls
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	codegen "github.com/streamingfast/substreams-codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestLookupMetrics(t *testing.T) {
+	response := `{"status":"1","message":"OK","result":[{"blockNumber":"12287507"}]}`
+	explorer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	}))
+	defer explorer.Close()
+
+	chain := &ChainConfig{ID: "lookup-metrics-test", ApiEndpoint: explorer.URL}
+	lookupErrors := func() float64 {
+		return testutil.ToFloat64(codegen.LookupErrors.Native().WithLabelValues(chain.ID, "initial_block"))
+	}
+
+	// the counters are global, kept from a previous run of the test
+	errorsBefore := lookupErrors()
+
+	block, err := getContractInitialBlock(context.Background(), chain, "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12287507), block)
+	assert.Equal(t, errorsBefore, lookupErrors())
+
+	response = `{"status":"0","message":"NOTOK","result":[]}`
+	_, err = getContractInitialBlock(context.Background(), chain, "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d")
+	require.Error(t, err)
+	assert.Equal(t, errorsBefore+1, lookupErrors())
+}
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	explorer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"0","message":"NOTOK","result":[]}`)
+	}))
+	defer explorer.Close()
+
+	chain := &ChainConfig{ID: "spans-test", ApiEndpoint: explorer.URL}
+	_, err := getContractInitialBlock(context.Background(), chain, "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d")
+	require.Error(t, err)
+
+	conv := loadProjectFromState(t, "./testdata/bayc.state.json")
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	codegen.TracedUpdate(context.Background(), conv, "evm-events-calls", codegen.AskProjectName{})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	lookup := spans[0]
+	assert.Equal(t, "lookup initial_block", lookup.Name())
+	assert.Contains(t, lookup.Attributes(), codegen.ChainAttribute.String("spans-test"))
+	assert.Contains(t, lookup.Attributes(), codegen.LookupAttribute.String("initial_block"))
+	assert.Equal(t, codes.Error, lookup.Status().Code)
+
+	update := spans[1]
+	assert.Equal(t, "Update", update.Name())
+	assert.Equal(t, []attribute.KeyValue{
+		codegen.GeneratorAttribute.String("evm-events-calls"),
+		codegen.MessageAttribute.String("codegen.AskProjectName"),
+		codegen.ChainAttribute.String("mainnet"),
+		codegen.ContractCountAttribute.Int(1),
+	}, update.Attributes())
+}
+
+func TestLookupLimit(t *testing.T) {
+	explorer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"1","message":"OK","result":[{"blockNumber":"12287507"}]}`)
+	}))
+	defer explorer.Close()
+
+	reached := 0
+	ctx := codegen.WithLookupLimit(context.Background(), 1, func() { reached++ })
+	chain := &ChainConfig{ID: "lookup-limit-test", ApiEndpoint: explorer.URL}
+
+	_, err := getContractInitialBlock(ctx, chain, "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d")
+	require.NoError(t, err)
+	assert.Equal(t, 0, reached)
+
+	for i := 0; i < 2; i++ {
+		_, err = getContractInitialBlock(ctx, chain, "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d")
+		assert.ErrorIs(t, err, codegen.ErrLookupLimit)
+	}
+	assert.Equal(t, 1, reached)
+}
@@ -1,16 +1,18 @@
 package evm_events_calls
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	codegen "github.com/streamingfast/substreams-codegen"
 	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
 	"golang.org/x/exp/maps"
 )
 
@@ -20,6 +22,17 @@ const UNISWAP_V3_FACTORY_ADDRESS = "0x1f98431c8ad98523631ae4a59f267346ea31f984"
 var QuitInvalidContext = loop.Quit(fmt.Errorf("invalid state context: no current contract"))
 var AbiFilepathPrefix = "file://"
 
+const (
+	// abiFetchTimeout bounds the lookup of an ABI, which can take several calls
+	// to the explorer (following proxies, waiting for its rate limit). The ABI
+	// is asked to the user once elapsed.
+	abiFetchTimeout = time.Minute
+	// initialBlockLookupTimeout bounds the lookup of the initial block of a
+	// contract, a single call to the explorer. The block is asked to the user
+	// once elapsed.
+	initialBlockLookupTimeout = 20 * time.Second
+)
+
 func init() {
 	codegen.RegisterConversation(
 		"evm-events-calls",
@@ -27,6 +40,28 @@ func init() {
 		"Given a list of contracts and their ABIs, this will build an Ethereum substreams that decodes events and/or calls",
 		codegen.ConversationFactory(New),
 		82,
+		codegen.InputChainName{},
+		InputContractSetup{},
+		InputContractAddress{},
+		InputContractName{},
+		InputContractInitialBlock{},
+		InputContractIsFactory{},
+		InputContractTrackWhat{},
+		InputContractABI{},
+		InputContractABIUpload{},
+		InputConfirmContractABI{},
+		InputContractEvents{},
+		InputContractCalls{},
+		InputFactoryCreationEvent{},
+		InputFactoryCreationEventField{},
+		InputDynamicContractName{},
+		InputDynamicContractAddress{},
+		InputDynamicContractTrackWhat{},
+		InputDynamicContractABI{},
+		InputDynamicContractABIUpload{},
+		InputDynamicContractEvents{},
+		InputDynamicContractCalls{},
+		InputAddContract{},
 	)
 }
 
@@ -77,6 +112,10 @@ func (c *Convo) NextStep() (out loop.Cmd) {
 			return next
 		}
 
+		if contract.Address == "" && contract.Name == "" && c.ClientSupports(codegen.ProtocolVersionForm) {
+			return cmd(AskContractSetup{})
+		}
+
 		if contract.Address == "" {
 			return cmd(AskContractAddress{})
 		}
@@ -108,6 +147,14 @@ func (c *Convo) NextStep() (out loop.Cmd) {
 			return notifyContext(cmd(AskContractTrackWhat{}))
 		}
 
+		if contract.SelectEventsCalls && contract.TrackEvents && contract.Events == nil {
+			return notifyContext(cmd(AskContractEvents{}))
+		}
+
+		if contract.SelectEventsCalls && contract.TrackCalls && contract.Calls == nil {
+			return notifyContext(cmd(AskContractCalls{}))
+		}
+
 		if contract.TrackFactory == nil {
 			return notifyContext(cmd(AskContractIsFactory{}))
 		}
@@ -133,7 +180,7 @@ func (c *Convo) NextStep() (out loop.Cmd) {
 			if !dynContract.TrackEvents && !dynContract.TrackCalls {
 				return notifyContext(cmd(AskDynamicContractTrackWhat{}))
 			}
-			if dynContract.Abi == nil {
+			if dynContract.Abi == nil || dynContract.Abi.abi == nil {
 				// if the user pasted an empty ABI, we would restart the process or choosing a contract address
 				if dynContract.emptyABI {
 					dynContract.referenceContractAddress = "" // reset the reference address
@@ -151,6 +198,14 @@ func (c *Convo) NextStep() (out loop.Cmd) {
 				}
 				return notifyContext(cmd(RunDecodeDynamicContractABI{}))
 			}
+
+			if dynContract.SelectEventsCalls && dynContract.TrackEvents && dynContract.Events == nil {
+				return notifyContext(cmd(AskDynamicContractEvents{}))
+			}
+
+			if dynContract.SelectEventsCalls && dynContract.TrackCalls && dynContract.Calls == nil {
+				return notifyContext(cmd(AskDynamicContractCalls{}))
+			}
 		}
 
 	}
@@ -161,7 +216,175 @@ func (c *Convo) NextStep() (out loop.Cmd) {
 		return cmd(AskAddContract{})
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
+}
+
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	var chainDisplayName string
+	if conf := p.ChainConfig(); conf != nil {
+		chainDisplayName = conf.DisplayName
+	}
+
+	fields := []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+		codegen.ChainNameField(&p.ChainName, chainDisplayName),
+	}
+	for _, contract := range p.Contracts {
+		fields = append(fields, contractEditableFields(p, contract)...)
+	}
+	fields = append(fields, codegen.EditableField{
+		Label: "Contracts",
+		Value: strings.Join(contractNames(p.Contracts), ", "),
+		Reset: func() { p.ConfirmEnoughContracts = false },
+	})
+	return fields
+}
+
+func contractEditableFields(p *Project, contract *Contract) []codegen.EditableField {
+	prefix := fmt.Sprintf("contract %q", contract.Name)
+	fields := []codegen.EditableField{
+		{
+			Label: prefix + " address",
+			Value: contract.Address,
+			Reset: contract.resetAddress,
+		},
+		{
+			Label: prefix + " initial block",
+			Value: formatInitialBlock(contract.InitialBlock),
+			Reset: func() { contract.InitialBlock = nil },
+		},
+		{
+			Label: prefix + " name",
+			Value: contract.Name,
+			Reset: func() { p.resetContractName(contract) },
+		},
+		{
+			Label: prefix + " tracking",
+			Value: trackWhat(&contract.BaseContract),
+			Reset: func() {
+				contract.TrackEvents = false
+				contract.TrackCalls = false
+				contract.Events = nil
+				contract.Calls = nil
+				contract.resetFactory()
+			},
+		},
+	}
+	fields = append(fields, selectionEditableFields(prefix, &contract.BaseContract)...)
+	isFactory := contract.TrackFactory != nil && *contract.TrackFactory
+	fields = append(fields, codegen.EditableField{
+		Label: prefix + " is a factory",
+		Value: strconv.FormatBool(isFactory),
+		Reset: contract.resetFactory,
+	})
+	if !isFactory {
+		return fields
+	}
+
+	creationEvent := contract.FactoryCreationEvent
+	if sig, found := contract.Abi.EventIDsToSig()[creationEvent]; found {
+		creationEvent = sig
+	}
+	fields = append(fields, codegen.EditableField{
+		Label: prefix + " creation event",
+		Value: creationEvent,
+		Reset: func() {
+			contract.FactoryCreationEvent = ""
+			contract.FactoryCreationEventFieldIdx = nil
+		},
+	}, codegen.EditableField{
+		Label: prefix + " creation event field",
+		Value: fmt.Sprintf("%d", *contract.FactoryCreationEventFieldIdx),
+		Reset: func() { contract.FactoryCreationEventFieldIdx = nil },
+	})
+
+	dynContract := p.dynamicContractOf(contract.Name)
+	dynPrefix := fmt.Sprintf("contracts created by %q", contract.Name)
+	fields = append(fields, codegen.EditableField{
+		Label: dynPrefix + ", name",
+		Value: dynContract.Name,
+		Reset: func() { dynContract.Name = "" },
+	}, codegen.EditableField{
+		Label: dynPrefix + ", tracking",
+		Value: trackWhat(&dynContract.BaseContract),
+		Reset: func() {
+			dynContract.TrackEvents = false
+			dynContract.TrackCalls = false
+			dynContract.Events = nil
+			dynContract.Calls = nil
+		},
+	})
+	if dynContract.Abi == nil || dynContract.Abi.abi == nil {
+		return fields
+	}
+	return append(fields, selectionEditableFields(dynPrefix+",", &dynContract.BaseContract)...)
+}
+
+// selectionEditableFields lists the events and calls picked for generation,
+// once they were asked.
+func selectionEditableFields(prefix string, contract *BaseContract) (out []codegen.EditableField) {
+	if contract.TrackEvents && contract.Events != nil {
+		out = append(out, codegen.EditableField{
+			Label: prefix + " events",
+			Value: selectionSummary(contract.Events, len(contract.allEventModels())),
+			Reset: func() { contract.Events = nil },
+		})
+	}
+	if contract.TrackCalls && contract.Calls != nil {
+		out = append(out, codegen.EditableField{
+			Label: prefix + " calls",
+			Value: selectionSummary(contract.Calls, len(contract.allCallModels())),
+			Reset: func() { contract.Calls = nil },
+		})
+	}
+	return
+}
+
+func selectionSummary(selected []string, total int) string {
+	if len(selected) == total {
+		return fmt.Sprintf("all %d", total)
+	}
+	if len(selected) == 0 {
+		return "none"
+	}
+	return strings.Join(selected, ", ")
+}
+
+// setTrackWhat applies the answer to the "what do you want to track" question.
+func setTrackWhat(contract *BaseContract, value string) error {
+	switch value {
+	case "events":
+		contract.TrackEvents = true
+	case "calls":
+		contract.TrackCalls = true
+	case "both":
+		contract.TrackEvents = true
+		contract.TrackCalls = true
+	default:
+		return fmt.Errorf("invalid selection input value %q, expected 'events', 'calls' or 'both'", value)
+	}
+	contract.SelectEventsCalls = true
+	return nil
+}
+
+func formatInitialBlock(initialBlock *uint64) string {
+	if initialBlock == nil {
+		return "(not set)"
+	}
+	return strconv.FormatUint(*initialBlock, 10)
+}
+
+func trackWhat(contract *BaseContract) string {
+	switch {
+	case contract.TrackEvents && contract.TrackCalls:
+		return "events and calls"
+	case contract.TrackCalls:
+		return "calls"
+	default:
+		return "events"
+	}
 }
 
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
@@ -177,7 +400,12 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				return loop.Quit(fmt.Errorf(`something went wrong, the initial state has not been validated: %w`, err))
 			}
 
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if msg.Unsigned {
+				c.State.resetUnvalidatedFields()
+				msgCmd = c.Msg().Message("Ok, I reloaded your state. It was not signed by this server, so the contract ABIs, initial blocks and factory events will be fetched or asked again, along with any invalid field.").Cmd()
+			} else if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -253,6 +481,86 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				Validation("^0x[a-fA-F0-9]{40}$", "Please enter a valid Ethereum address: 0x followed by 40 hex characters.").Cmd(),
 		)
 
+	case AskContractSetup:
+		config := c.State.ChainConfig()
+		initialBlock := codegen.NewFormField().TextInput("Initial block", "")
+		if config.ApiEndpoint != "" {
+			initialBlock.Description("The block at which the contract was created. Leave empty to look it up on the explorer.").
+				Validation(`^\d*$`, "Please enter a valid block number, or leave it empty")
+		} else {
+			initialBlock.Description("The block at which the contract was created.").
+				Validation(`^\d+$`, "Please enter a valid block number")
+		}
+
+		form := c.Action(InputContractSetup{}).Form("Please describe the contract", "Submit").
+			Field("address", codegen.NewFormField().TextInput("Contract address", "").
+				Description("Format it with 0x prefix and make sure it's a valid Ethereum address.\nThe default value is an example contract of this chain.").
+				DefaultValue(config.ExampleContract).
+				Validation("^0x[a-fA-F0-9]{40}$", "Please enter a valid Ethereum address: 0x followed by 40 hex characters.")).
+			Field("name", codegen.NewFormField().TextInput("Short name", "").
+				Description("Lowercase and numbers only").
+				Validation(`^([a-z][a-z0-9_]{0,63})$`, "The name should be short, and contain only lowercase characters and numbers, and not start with a number.")).
+			Field("initial_block", initialBlock)
+		if config.SupportsCalls {
+			form.Field("track", codegen.NewFormField().ListSelect("What do you want to track for this contract?").
+				Labels("Events", "Calls", "Both events and calls").
+				Values("events", "calls", "both").
+				DefaultValue("events"))
+		}
+
+		return loop.Seq(
+			c.Msg().Messagef("We're tackling the %s contract.", humanize.Ordinal(c.State.currentContractIdx+1)).Cmd(),
+			form.Cmd(),
+		)
+
+	case InputContractSetup:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+
+		// Valid fields are kept, the invalid ones are asked again one at a time.
+		var out []loop.Cmd
+		inputAddress := strings.ToLower(msg.TextValue("address"))
+		if err := validateContractAddress(c.State, inputAddress); err != nil {
+			out = append(out, cmd(MsgInvalidContractAddress{err}))
+		} else {
+			contract.Address = inputAddress
+		}
+
+		if err := validateContractName(c.State, msg.TextValue("name")); err != nil {
+			out = append(out, cmd(MsgInvalidContractName{err}))
+		} else {
+			contract.Name = msg.TextValue("name")
+			c.State.relinkDynamicContract(contract)
+		}
+
+		if value := msg.TextValue("initial_block"); value != "" {
+			blk, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				// too large for a block number, it is asked again (or looked up)
+				out = append(out, c.Msg().Messagef("Cannot parse the block number %q: %s", value, err).Cmd())
+			} else {
+				contract.InitialBlock = &blk
+			}
+		} else {
+			contract.lookupInitialBlock = true
+		}
+
+		if c.State.ChainConfig().SupportsCalls {
+			if err := setTrackWhat(&contract.BaseContract, msg.SelectionValue("track")); err != nil {
+				return loop.Quit(err)
+			}
+		} else {
+			contract.TrackEvents = true
+			contract.SelectEventsCalls = true
+		}
+
+		if len(out) == 0 {
+			return c.NextStep()
+		}
+		return loop.Seq(append(out, c.NextStep())...)
+
 	case AskDynamicContractAddress:
 		factory := c.contextContract()
 		if factory == nil {
@@ -280,6 +588,17 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		return c.NextStep()
 
 	case AskContractABI:
+		if c.ClientSupports(codegen.ProtocolVersionUpload) {
+			return c.Action(InputContractABIUpload{}).Upload("Please upload the contract ABI", "Upload").
+				Description("Either the JSON ABI file, or the Hardhat or Foundry build artifact of the contract (JSON)").
+				AcceptedMimeTypes(codegen.ABIMimeTypes...).
+				MaxSize(codegen.MaxABIUploadSize).
+				Cmd()
+		}
+		if !c.LocalFilesEnabled() {
+			return c.Action(InputContractABI{}).TextInput("Please paste the contract ABI", "Submit").
+				Cmd()
+		}
 		return c.Action(InputContractABI{}).TextInput(fmt.Sprintf("Please paste the contract ABI or the full JSON ABI file path starting with %sfullpath/to/Abi.json", AbiFilepathPrefix), "Submit").
 			Cmd()
 
@@ -289,9 +608,31 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 			return QuitInvalidContext
 		}
 
+		if c.ClientSupports(codegen.ProtocolVersionUpload) {
+			return c.Action(InputDynamicContractABIUpload{}).Upload(fmt.Sprintf("Please upload the ABI for contracts that will be created by the event %q", contract.FactoryCreationEventName()), "Upload").
+				Description("Either the JSON ABI file, or the Hardhat or Foundry build artifact of the contract (JSON)").
+				AcceptedMimeTypes(codegen.ABIMimeTypes...).
+				MaxSize(codegen.MaxABIUploadSize).
+				Cmd()
+		}
+
 		return c.Action(InputDynamicContractABI{}).TextInput(fmt.Sprintf("Please paste the ABI for contracts that will be created by the event %q", contract.FactoryCreationEventName()), "Submit").
 			Cmd()
 
+	case InputContractABIUpload:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+
+		rawMessage, err := codegen.ABIFromFile(msg.Content)
+		if err != nil {
+			return loop.Seq(c.Msg().Messagef("Cannot read the ABI from %q: %s", msg.Filename, err).Cmd(), cmd(AskContractABI{}))
+		}
+
+		contract.RawABI = rawMessage
+		return c.NextStep()
+
 	case InputContractABI:
 		contract := c.contextContract()
 		if contract == nil {
@@ -310,7 +651,7 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		if strings.HasPrefix(msg.Value, AbiFilepathPrefix) {
 			abiPath := strings.TrimPrefix(msg.Value, AbiFilepathPrefix)
 
-			fileBytes, err := os.ReadFile(abiPath)
+			fileBytes, err := c.ReadLocalFile(abiPath)
 			if err != nil {
 				return loop.Seq(c.Msg().Messagef("Cannot read the ABI file %q: %s", abiPath, err).Cmd(), cmd(AskContractABI{}))
 			}
@@ -328,6 +669,20 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 
 		return c.NextStep()
 
+	case InputDynamicContractABIUpload:
+		factory := c.contextContract()
+		if factory == nil {
+			return QuitInvalidContext
+		}
+
+		rawMessage, err := codegen.ABIFromFile(msg.Content)
+		if err != nil {
+			return loop.Seq(c.Msg().Messagef("Cannot read the ABI from %q: %s", msg.Filename, err).Cmd(), cmd(AskDynamicContractABI{}))
+		}
+
+		c.State.dynamicContractOf(factory.Name).RawABI = rawMessage
+		return c.NextStep()
+
 	case InputDynamicContractABI:
 		factory := c.contextContract()
 		if factory == nil {
@@ -379,10 +734,10 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 			return cmd(AskContractABI{})
 		}
 
-		return func() loop.Msg {
-			abi, err := contract.FetchABI(config)
+		return loop.WithContextTimeout(abiFetchTimeout, func(ctx context.Context) loop.Msg {
+			abi, err := contract.FetchABI(ctx, config)
 			return ReturnFetchContractABI{abi: abi, err: err}
-		}
+		})
 
 	case ReturnFetchContractABI:
 		contract := c.contextContract()
@@ -409,10 +764,10 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		if config.ApiEndpoint == "" {
 			return cmd(AskDynamicContractABI{})
 		}
-		return func() loop.Msg {
-			abi, err := contract.FetchABI(c.State.ChainConfig())
+		return loop.WithContextTimeout(abiFetchTimeout, func(ctx context.Context) loop.Msg {
+			abi, err := contract.FetchABI(ctx, config)
 			return ReturnFetchDynamicContractABI{abi: abi, err: err}
-		}
+		})
 
 	case ReturnFetchDynamicContractABI:
 		factory := c.contextContract()
@@ -453,31 +808,7 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 			return c.NextStep()
 		}
 
-		// the 'printf' is a hack because we can't do arithmetics in the template
-		// it means '+1'
-		peekABI := c.Msg().MessageTpl(`Ok, here's what the ABI would produce:
-
-`+"```"+`protobuf
-// Events
-{{- range .events }}
-message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
-  {{- range $idx, $field := .Proto.Fields }}
-  {{$field.Type}} {{$field.Name}} = {{ len (printf "a%*s" $idx "") }};
-  {{- end}}
-}
-{{- end}}
-
-// Calls
-{{- range .calls }}
-message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
-  {{- range $idx, $field := .Proto.Fields }}
-  {{$field.Type}} {{$field.Name}} = {{ len (printf "a%*s" $idx "") }};
-  {{- end}}
-}
-{{- end}}
-`+"```"+`
-`, map[string]any{"events": evt, "calls": calls}).Cmd()
-		return loop.Seq(peekABI, cmd(AskConfirmContractABI{}))
+		return loop.Seq(c.cmdPreviewABI(evt, calls), cmd(AskConfirmContractABI{}))
 
 	case AskConfirmContractABI:
 		return c.Action(InputConfirmContractABI{}).
@@ -521,31 +852,7 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 		if !contract.abiFetchedInThisSession {
 			return c.NextStep()
 		}
-		// the 'printf' is a hack because we can't do arithmetics in the template
-		// it means '+1'
-		peekABI := c.Msg().MessageTpl(`Ok, here's what the ABI would produce:
-
-`+"```"+`protobuf
-// Events
-{{- range .events }}
-message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
-  {{- range $idx, $field := .Proto.Fields }}
-  {{$field.Type}} ({{$field.Name}}) = {{ len (printf "a%*s" $idx "") }};
-  {{- end}}
-}
-{{- end}}
-
-// Calls
-{{- range .calls }}
-message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
-  {{- range $idx, $field := .Proto.Fields }}
-  {{$field.Type}} ({{$field.Name}}) = {{ len (printf "a%*s" $idx "") }};
-  {{- end}}
-}
-{{- end}}
-`+"```"+`
-		`, map[string]any{"events": evt, "calls": calls}).Cmd()
-		return loop.Seq(peekABI, c.NextStep())
+		return loop.Seq(c.cmdPreviewABI(evt, calls), c.NextStep())
 
 	case FetchContractInitialBlock:
 		contract := c.contextContract()
@@ -556,10 +863,10 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 		if config.ApiEndpoint == "" {
 			return cmd(AskContractInitialBlock{})
 		}
-		return func() loop.Msg {
-			initialBlock, err := contract.FetchInitialBlock(config)
+		return loop.WithContextTimeout(initialBlockLookupTimeout, func(ctx context.Context) loop.Msg {
+			initialBlock, err := contract.FetchInitialBlock(ctx, config)
 			return ReturnFetchContractInitialBlock{InitialBlock: initialBlock, Err: err}
-		}
+		})
 
 	case AskContractInitialBlock:
 		return c.Action(InputContractInitialBlock{}).TextInput("Please enter the contract initial block number", "Submit").
@@ -587,6 +894,15 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 			return QuitInvalidContext
 		}
 
+		if contract.lookupInitialBlock && msg.Err == nil {
+			contract.lookupInitialBlock = false
+			contract.InitialBlock = &msg.InitialBlock
+			return loop.Seq(
+				c.Msg().Messagef("Using initial block %d, where the contract was created.", msg.InitialBlock).Cmd(),
+				c.NextStep(),
+			)
+		}
+
 		return c.Action(InputContractInitialBlock{}).TextInput("Please enter the contract initial block number", "Submit").
 			DefaultValue(fmt.Sprintf("%d", msg.InitialBlock)).
 			Validation(`^\d+$`, "Please enter a valid block number").
@@ -615,6 +931,7 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 			return loop.Seq(cmd(MsgInvalidContractName{err}), cmd(AskContractName{}))
 		}
 		contract.Name = msg.Value
+		c.State.relinkDynamicContract(contract)
 		return c.NextStep()
 
 	case MsgInvalidContractName:
@@ -667,6 +984,7 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 		if !c.State.ChainConfig().SupportsCalls {
 			contract.TrackEvents = true
 			contract.TrackCalls = false
+			contract.SelectEventsCalls = true
 			return c.NextStep()
 		}
 		act := c.Action(InputContractTrackWhat{}).
@@ -683,16 +1001,8 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 		if contract == nil {
 			return QuitInvalidContext
 		}
-		switch msg.Value {
-		case "events":
-			contract.TrackEvents = true
-		case "calls":
-			contract.TrackCalls = true
-		case "both":
-			contract.TrackEvents = true
-			contract.TrackCalls = true
-		default:
-			return loop.Quit(fmt.Errorf("invalid selection input value %q, expected 'events', 'calls' or 'both'", msg.Value))
+		if err := setTrackWhat(&contract.BaseContract, msg.Value); err != nil {
+			return loop.Quit(err)
 		}
 		return c.NextStep()
 
@@ -715,16 +1025,88 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 			return QuitInvalidContext
 		}
 		contract := c.State.dynamicContractOf(factory.Name)
-		switch msg.Value {
-		case "events":
-			contract.TrackEvents = true
-		case "calls":
-			contract.TrackCalls = true
-		case "both":
-			contract.TrackEvents = true
-			contract.TrackCalls = true
-		default:
-			return loop.Quit(fmt.Errorf("invalid selection input value %q, expected 'events', 'calls' or 'both'", msg.Value))
+		if err := setTrackWhat(&contract.BaseContract, msg.Value); err != nil {
+			return loop.Quit(err)
+		}
+		return c.NextStep()
+
+	case AskContractEvents:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+		return c.cmdAskEvents(InputContractEvents{}, &contract.BaseContract, fmt.Sprintf("contract %q", contract.Name))
+
+	case InputContractEvents:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+		contract.Events = selection(&msg.UserInput_Selection)
+		if contract.generatesNothing() {
+			contract.Events = nil
+			return c.cmdSelectAtLeastOne(fmt.Sprintf("contract %q", contract.Name), AskContractEvents{})
+		}
+		return c.NextStep()
+
+	case AskContractCalls:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+		return c.cmdAskCalls(InputContractCalls{}, &contract.BaseContract, fmt.Sprintf("contract %q", contract.Name))
+
+	case InputContractCalls:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+		contract.Calls = selection(&msg.UserInput_Selection)
+		if contract.generatesNothing() {
+			contract.Calls = nil
+			return c.cmdSelectAtLeastOne(fmt.Sprintf("contract %q", contract.Name), AskContractCalls{})
+		}
+		return c.NextStep()
+
+	case AskDynamicContractEvents:
+		factory := c.contextContract()
+		if factory == nil {
+			return QuitInvalidContext
+		}
+		contract := c.State.dynamicContractOf(factory.Name)
+		return c.cmdAskEvents(InputDynamicContractEvents{}, &contract.BaseContract, fmt.Sprintf("the contracts created by %q", factory.Name))
+
+	case InputDynamicContractEvents:
+		factory := c.contextContract()
+		if factory == nil {
+			return QuitInvalidContext
+		}
+		contract := c.State.dynamicContractOf(factory.Name)
+		contract.Events = selection(&msg.UserInput_Selection)
+		if contract.generatesNothing() {
+			contract.Events = nil
+			return c.cmdSelectAtLeastOne(fmt.Sprintf("the contracts created by %q", factory.Name), AskDynamicContractEvents{})
+		}
+		return c.NextStep()
+
+	case AskDynamicContractCalls:
+		factory := c.contextContract()
+		if factory == nil {
+			return QuitInvalidContext
+		}
+		contract := c.State.dynamicContractOf(factory.Name)
+		return c.cmdAskCalls(InputDynamicContractCalls{}, &contract.BaseContract, fmt.Sprintf("the contracts created by %q", factory.Name))
+
+	case InputDynamicContractCalls:
+		factory := c.contextContract()
+		if factory == nil {
+			return QuitInvalidContext
+		}
+		contract := c.State.dynamicContractOf(factory.Name)
+		contract.Calls = selection(&msg.UserInput_Selection)
+		if contract.generatesNothing() {
+			contract.Calls = nil
+			return c.cmdSelectAtLeastOne(fmt.Sprintf("the contracts created by %q", factory.Name), AskDynamicContractCalls{})
 		}
 		return c.NextStep()
 
@@ -748,6 +1130,9 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 		}
 
 		contract.TrackFactory = &msg.Affirmative
+		if !msg.Affirmative {
+			c.State.removeDynamicContractOf(contract.Name)
+		}
 		return c.NextStep()
 
 	case AskFactoryCreationEvent:
@@ -852,6 +1237,13 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 		}
 		return c.NextStep()
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
@@ -863,4 +1255,100 @@ message {{.Proto.MessageName}} {{.Proto.OutputModuleFieldName}} {
 	return loop.Quit(fmt.Errorf("invalid loop message: %T", msg))
 }
 
+// cmdPreviewABI shows the messages that the events and calls of the ABI would
+// produce, with their fields.
+func (c *Convo) cmdPreviewABI(events []codegenEvent, calls []codegenCall) loop.Cmd {
+	out := []loop.Cmd{c.Msg().Message("Ok, here's what the ABI would produce:").Cmd()}
+	if len(events) != 0 {
+		table := c.Msg().Table("Events", "Message", "Field", "Type")
+		for _, evt := range events {
+			addPreviewRows(table, evt.Proto.MessageName, evt.Proto.Fields)
+		}
+		out = append(out, table.Cmd())
+	}
+	if len(calls) != 0 {
+		table := c.Msg().Table("Calls", "Message", "Field", "Type")
+		for _, call := range calls {
+			addPreviewRows(table, call.Proto.MessageName, call.Proto.Fields)
+		}
+		out = append(out, table.Cmd())
+	}
+	return loop.Seq(out...)
+}
+
+func addPreviewRows(table *codegen.MsgWrap, messageName string, fields []protoField) {
+	if len(fields) == 0 {
+		table.Row(messageName, "", "")
+		return
+	}
+	for i, field := range fields {
+		if i != 0 {
+			messageName = ""
+		}
+		table.Row(messageName, field.Name, field.Type)
+	}
+}
+
+// cmdAskEvents asks which events of the contract to generate, all of them
+// being selected by default. There is nothing to ask when the ABI has no events.
+func (c *Convo) cmdAskEvents(input any, contract *BaseContract, name string) loop.Cmd {
+	var labels, values []string
+	for _, evt := range contract.allEventModels() {
+		labels = append(labels, evt.Signature)
+		values = append(values, evt.ID())
+	}
+	if len(values) == 0 {
+		contract.Events = []string{}
+		return c.NextStep()
+	}
+
+	return c.Action(input).ListSelect(fmt.Sprintf("Which events of %s do you want to generate?", name)).
+		SelectMany().
+		Labels(labels...).
+		Values(values...).
+		DefaultValues(values...).
+		Cmd()
+}
+
+// cmdAskCalls asks which calls (non-view functions) of the contract to
+// generate, all of them being selected by default. There is nothing to ask when
+// the ABI has no such function.
+func (c *Convo) cmdAskCalls(input any, contract *BaseContract, name string) loop.Cmd {
+	var labels, values []string
+	for _, call := range contract.allCallModels() {
+		labels = append(labels, call.Signature)
+		values = append(values, call.ID())
+	}
+	if len(values) == 0 {
+		contract.Calls = []string{}
+		return c.NextStep()
+	}
+
+	return c.Action(input).ListSelect(fmt.Sprintf("Which functions of %s do you want to generate calls for?", name)).
+		SelectMany().
+		Labels(labels...).
+		Values(values...).
+		DefaultValues(values...).
+		Cmd()
+}
+
+// selection returns the selected values, never nil so that an empty selection
+// is not asked again.
+// cmdSelectAtLeastOne asks again for the events or calls of a contract, when
+// nothing would be generated for it.
+func (c *Convo) cmdSelectAtLeastOne(name string, ask any) loop.Cmd {
+	return loop.Seq(
+		c.Msg().Messagef("Nothing would be generated for %s, please select at least one event or call.", name).Cmd(),
+		cmd(ask),
+	)
+}
+
+func selection(input *pbconvo.UserInput_Selection) []string {
+	values := input.SelectedValues()
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 var cmd = codegen.Cmd
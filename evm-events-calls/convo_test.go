@@ -1,11 +1,18 @@
 package evm_events_calls
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/streamingfast/eth-go"
 	codegen "github.com/streamingfast/substreams-codegen"
+	"github.com/streamingfast/substreams-codegen/codegentest"
 	"github.com/streamingfast/substreams-codegen/loop"
 	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
 	"github.com/stretchr/testify/assert"
@@ -42,13 +49,13 @@ func TestConvoUpdate(t *testing.T) {
 	conv.SetFactory(&codegen.MsgWrapFactory{})
 	p := conv.(*Convo).State
 
-	next := conv.Update(codegen.InputProjectName{pbconvo.UserInput_TextInput{
+	next := conv.Update(codegen.InputProjectName{UserInput_TextInput: pbconvo.UserInput_TextInput{
 		Value: "my-proj",
 	}})
 	assert.Equal(t, "my-proj", p.Name)
 
 	assert.Equal(t, codegen.AskChainName{}, next())
-	next = conv.Update(codegen.InputChainName{pbconvo.UserInput_Selection{
+	next = conv.Update(codegen.InputChainName{UserInput_Selection: pbconvo.UserInput_Selection{
 		Value: "mainnet",
 	}})
 	assert.Equal(t, "mainnet", p.ChainName)
@@ -67,7 +74,7 @@ func TestConvoUpdate(t *testing.T) {
 	assert.Equal(t, FetchContractABI{}, next())
 
 	next = conv.Update(FetchContractABI{})
-	decode := next().(ReturnFetchContractABI)
+	decode := next().(loop.WithContextMsg).Run(context.Background()).(ReturnFetchContractABI)
 
 	assert.NotNil(t, decode.err)
 
@@ -98,6 +105,10 @@ func TestConvoUpdate(t *testing.T) {
 	assert.Equal(t, AskContractTrackWhat{}, next())
 
 	next = conv.Update(InputContractTrackWhat{UserInput_Selection: pbconvo.UserInput_Selection{Value: "calls"}})
+	assert.Equal(t, AskContractCalls{}, next())
+
+	// the ABI has no calls to select from
+	next = conv.Update(AskContractCalls{})
 	assert.Equal(t, AskContractIsFactory{}, next())
 
 	next = conv.Update(InputContractIsFactory{UserInput_Confirmation: pbconvo.UserInput_Confirmation{Affirmative: true}})
@@ -129,9 +140,12 @@ func TestConvoUpdate(t *testing.T) {
 		abi: &eth.ABI{},
 		raw: "[]",
 	}, err: nil})
+	assert.Equal(t, AskDynamicContractEvents{}, next())
 
+	next = conv.Update(AskDynamicContractEvents{})
 	assert.Equal(t, AskAddContract{}, next())
 
+	// Clients older than the review step generate right away
 	next = conv.Update(InputAddContract{UserInput_Confirmation: pbconvo.UserInput_Confirmation{Affirmative: false}})
 	assert.Equal(t, codegen.RunGenerate{}, next())
 
@@ -139,7 +153,8 @@ func TestConvoUpdate(t *testing.T) {
 	seq = next().(loop.SeqMsg)
 
 	//cmds := next()
-	msg1 := seq[1]().(*pbconvo.SystemOutput)
+	assert.NotNil(t, seq[1]().(*pbconvo.SystemOutput).GetMessage(), "file tree rendered as markdown for older clients")
+	msg1 := seq[2]().(*pbconvo.SystemOutput)
 
 	assert.Contains(t, msg1.GetMessage().Markdown, "substreams build\nsubstreams auth\nsubstreams gui")
 	//msg2 := seq[1]().(*pbconvo.SystemOutput)
@@ -239,3 +254,607 @@ func TestDynamicContractAddressAlreadyExists(t *testing.T) {
 
 	assert.IsType(t, AskDynamicContractAddress{}, seq[1])
 }
+
+func TestUnsignedHydrateDropsABIs(t *testing.T) {
+	savedState := `{"name":"my-proj","chainName":"mainnet","contracts":[{"name":"bayc","address":"0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d","rawAbi":[]}]}`
+
+	conv := New()
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	conv.Update(codegen.MsgStart{
+		UserInput_Start: pbconvo.UserInput_Start{Hydrate: &pbconvo.UserInput_Hydrate{SavedState: savedState}},
+	})
+	assert.NotNil(t, conv.(*Convo).State.Contracts[0].RawABI)
+
+	conv = New()
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	conv.Update(codegen.MsgStart{
+		UserInput_Start: pbconvo.UserInput_Start{Hydrate: &pbconvo.UserInput_Hydrate{SavedState: savedState}},
+		Unsigned:        true,
+	})
+	assert.Nil(t, conv.(*Convo).State.Contracts[0].RawABI)
+}
+
+func TestUnsignedHydrateResetsUnvalidatedFields(t *testing.T) {
+	savedState := `{
+		"name": "../my proj",
+		"chainName": "mainnet",
+		"contracts": [
+			{"name": "factory", "address": "0x1f98431c8ad98523631ae4a59f267346ea31f984", "initialBlock": 1, "trackEvents": true, "trackFactory": true, "factoryCreationEvent": "forged", "factoryCreationEventFieldIdx": 9},
+			{"name": "Bad Name", "address": "not-an-address", "initialBlock": 2, "trackEvents": true, "trackFactory": false}
+		],
+		"dynamic_contracts": [{"name": "pool;", "parentContractName": "factory", "trackEvents": true}]
+	}`
+
+	conv := New().(*Convo)
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	conv.Update(codegen.MsgStart{
+		UserInput_Start: pbconvo.UserInput_Start{Hydrate: &pbconvo.UserInput_Hydrate{SavedState: savedState}},
+		Unsigned:        true,
+	})
+
+	p := conv.State
+	assert.Empty(t, p.Name)
+	assert.Equal(t, "mainnet", p.ChainName)
+
+	factory := p.Contracts[0]
+	assert.Equal(t, "factory", factory.Name)
+	assert.Equal(t, "0x1f98431c8ad98523631ae4a59f267346ea31f984", factory.Address)
+	assert.Nil(t, factory.InitialBlock)
+	assert.True(t, *factory.TrackFactory)
+	assert.Empty(t, factory.FactoryCreationEvent)
+	assert.Nil(t, factory.FactoryCreationEventFieldIdx)
+
+	assert.Empty(t, p.Contracts[1].Name)
+	assert.Empty(t, p.Contracts[1].Address)
+	assert.Nil(t, p.Contracts[1].InitialBlock)
+
+	assert.Empty(t, p.DynamicContracts[0].Name)
+	assert.Equal(t, "factory", p.DynamicContracts[0].ParentContractName)
+
+	assert.Equal(t, codegen.AskProjectName{}, conv.NextStep()())
+}
+
+func TestGoBack(t *testing.T) {
+	conv := New()
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	p := conv.(*Convo).State
+
+	conv.PushSnapshot()
+	conv.Update(codegen.InputProjectName{UserInput_TextInput: pbconvo.UserInput_TextInput{Value: "my-proj"}})
+	conv.PushSnapshot()
+	conv.Update(codegen.InputChainName{UserInput_Selection: pbconvo.UserInput_Selection{Value: "arbitrum"}})
+	assert.Equal(t, "arbitrum", p.ChainName)
+
+	// An answer leaving the state untouched is undone on its own
+	conv.PushSnapshot()
+	require.True(t, conv.PopSnapshot())
+	assert.Equal(t, "arbitrum", p.ChainName)
+
+	seq := codegen.CmdGoBack(conv, codegen.NewMsgWrapFactory(nil))().(loop.SeqMsg)
+	assert.Equal(t, "Ok, let's go back.", seq[0]().(*pbconvo.SystemOutput).GetMessage().GetMarkdown())
+	assert.Equal(t, codegen.AskChainName{}, seq[1]())
+	assert.Equal(t, "my-proj", p.Name)
+	assert.Equal(t, "", p.ChainName)
+
+	require.True(t, conv.PopSnapshot())
+	assert.Equal(t, "", p.Name)
+	assert.False(t, conv.PopSnapshot())
+}
+
+func TestRenameFactoryAfterGoBack(t *testing.T) {
+	conv := New()
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	p := conv.(*Convo).State
+
+	isFactory := true
+	p.Contracts = []*Contract{{BaseContract: BaseContract{Name: "factory"}, TrackFactory: &isFactory}}
+	p.DynamicContracts = []*DynamicContract{{BaseContract: BaseContract{Name: "pool"}, ParentContractName: "factory"}}
+
+	// Renaming the factory from the review, then going back to answer its name
+	// again: the state restored from the snapshot keeps the link to the
+	// dynamic contract.
+	p.resetContractName(p.Contracts[0])
+	conv.PushSnapshot()
+	p.Contracts[0].Name = "first"
+	p.relinkDynamicContract(p.Contracts[0])
+	require.True(t, conv.PopSnapshot())
+
+	p.Contracts[0].Name = "second"
+	p.relinkDynamicContract(p.Contracts[0])
+	require.Len(t, p.DynamicContracts, 1)
+	assert.Equal(t, "second", p.DynamicContracts[0].ParentContractName)
+}
+
+func TestGoBackKeepsSessionState(t *testing.T) {
+	conv := New()
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	p := conv.(*Convo).State
+
+	isFactory := true
+	factory := &Contract{BaseContract: BaseContract{Name: "factory", abiFetchedInThisSession: true, Events: []string{"a"}}, TrackFactory: &isFactory, lookupInitialBlock: true}
+	p.Contracts = []*Contract{factory}
+	p.DynamicContracts = []*DynamicContract{{BaseContract: BaseContract{Name: "pool"}, ParentContractName: "factory", parentContract: factory}}
+
+	conv.PushSnapshot()
+	factory.Events[0] = "b"
+	*factory.TrackFactory = false
+	factory.abiFetchedInThisSession = false
+	factory.lookupInitialBlock = false
+	p.DynamicContracts[0].parentContract = nil
+	require.True(t, conv.PopSnapshot())
+
+	restored := p.Contracts[0]
+	assert.Equal(t, []string{"a"}, restored.Events)
+	assert.True(t, *restored.TrackFactory)
+	assert.True(t, restored.abiFetchedInThisSession)
+	assert.True(t, restored.lookupInitialBlock)
+	assert.Same(t, restored, p.DynamicContracts[0].ParentContract())
+}
+
+func TestContractABIUpload(t *testing.T) {
+	abi, err := os.ReadFile("./testdata/bayc_contract.abi.json")
+	require.NoError(t, err)
+
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetClientVersion(codegen.ProtocolVersionUpload)
+	conv := New()
+	conv.SetFactory(factory)
+	p := conv.(*Convo).State
+	p.Name = "my-proj"
+	p.ChainName = "mainnet"
+	p.currentContractIdx = 0
+	p.Contracts = append(p.Contracts, &Contract{Address: "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"})
+
+	prompt := conv.Update(AskContractABI{})().(*pbconvo.SystemOutput)
+	require.NotNil(t, prompt.GetUpload())
+	assert.Equal(t, codegen.ABIMimeTypes, prompt.GetUpload().AcceptedMimeTypes)
+
+	artifact := fmt.Sprintf(`{"_format":"hh-sol-artifact-1","contractName":"BoredApeYachtClub","abi":%s,"bytecode":"0x"}`, abi)
+	msg, err := factory.DecodeInput(&pbconvo.UserInput{Entry: &pbconvo.UserInput_File{File: &pbconvo.UserInput_Upload{
+		Filename: "BoredApeYachtClub.json",
+		Content:  []byte(artifact),
+	}}})
+	require.NoError(t, err)
+	require.IsType(t, InputContractABIUpload{}, msg.Msg)
+
+	assert.Equal(t, RunDecodeContractABI{}, conv.Update(msg.Msg)())
+	assert.JSONEq(t, string(abi), string(p.Contracts[0].RawABI))
+
+	msg, err = factory.DecodeInput(&pbconvo.UserInput{Entry: &pbconvo.UserInput_File{File: &pbconvo.UserInput_Upload{
+		Filename: "abi.txt",
+		Content:  abi,
+	}}})
+	require.NoError(t, err)
+	assert.Contains(t, msg.Msg.(codegen.MsgInvalidInput).Reason, `expected one of: application/json`)
+}
+
+func TestContractABILocalFile(t *testing.T) {
+	newConvo := func(localFilesRoot string) (codegen.Converser, *Project) {
+		factory := codegen.NewMsgWrapFactory(nil)
+		factory.SetLocalFilesRoot(localFilesRoot)
+		conv := New()
+		conv.SetFactory(factory)
+		p := conv.(*Convo).State
+		p.Name = "my-proj"
+		p.ChainName = "mainnet"
+		p.currentContractIdx = 0
+		p.Contracts = append(p.Contracts, &Contract{Address: "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"})
+		return conv, p
+	}
+	input := func(value string) InputContractABI {
+		return InputContractABI{pbconvo.UserInput_TextInput{Value: value}}
+	}
+
+	conv, p := newConvo("")
+	assert.NotContains(t, conv.Update(AskContractABI{})().(*pbconvo.SystemOutput).GetTextInput().Prompt, AbiFilepathPrefix)
+	seq := conv.Update(input("file://testdata/bayc_contract.abi.json"))().(loop.SeqMsg)
+	assert.Contains(t, seq[0]().(*pbconvo.SystemOutput).GetMessage().Markdown, codegen.ErrLocalFilesDisabled.Error())
+	assert.Nil(t, p.Contracts[0].RawABI)
+
+	conv, p = newConvo("./testdata")
+	seq = conv.Update(input("file://../convo.go"))().(loop.SeqMsg)
+	assert.Contains(t, seq[0]().(*pbconvo.SystemOutput).GetMessage().Markdown, "outside of the allowed directory")
+	outside, err := filepath.Abs("convo.go")
+	require.NoError(t, err)
+	seq = conv.Update(input(AbiFilepathPrefix + outside))().(loop.SeqMsg)
+	assert.Contains(t, seq[0]().(*pbconvo.SystemOutput).GetMessage().Markdown, "outside of the allowed directory")
+
+	assert.Equal(t, RunDecodeContractABI{}, conv.Update(input("file://bayc_contract.abi.json"))())
+	assert.NotNil(t, p.Contracts[0].RawABI)
+}
+
+func TestContractEventsCallsSelection(t *testing.T) {
+	conv := loadProjectFromState(t, "./testdata/bayc.state.json")
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	p := conv.State
+	contract := p.Contracts[0]
+	res := CmdDecodeABI(contract)().(ReturnRunDecodeContractABI)
+	require.NoError(t, res.Err)
+	contract.Abi = res.Abi
+	// as if the user just said what to track
+	contract.SelectEventsCalls = true
+
+	allEvents := len(contract.EventModels())
+	allCalls := len(contract.CallModels())
+
+	prompt := conv.Update(AskContractEvents{})().(*pbconvo.SystemOutput).GetListSelect()
+	require.NotNil(t, prompt)
+	assert.True(t, prompt.SelectMany)
+	assert.Len(t, prompt.Values, allEvents)
+	assert.Equal(t, prompt.Values, prompt.DefaultValues)
+	assert.Contains(t, prompt.Labels, "Transfer(address,address,uint256)")
+
+	next := conv.Update(InputContractEvents{pbconvo.UserInput_Selection{Values: []string{"Transfer"}}})
+	assert.Equal(t, AskContractCalls{}, next())
+	require.Len(t, contract.EventModels(), 1)
+	assert.Equal(t, "Transfer", contract.EventModels()[0].ID())
+
+	next = conv.Update(InputContractCalls{pbconvo.UserInput_Selection{}})
+	assert.Equal(t, AskAddContract{}, next())
+	assert.Empty(t, contract.CallModels())
+	assert.Len(t, contract.allCallModels(), allCalls)
+
+	res2 := p.Generate(context.Background())
+	require.NoError(t, res2.Err)
+	proto := string(res2.ProjectFiles["proto/contract.proto"])
+	assert.Contains(t, proto, "Transfer")
+	assert.NotContains(t, proto, "ApprovalForAll")
+}
+
+func TestEmptyEventsCallsSelection(t *testing.T) {
+	conv := loadProjectFromState(t, "./testdata/bayc.state.json")
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	contract := conv.State.Contracts[0]
+	res := CmdDecodeABI(contract)().(ReturnRunDecodeContractABI)
+	require.NoError(t, res.Err)
+	contract.Abi = res.Abi
+	contract.SelectEventsCalls = true
+
+	// No event is fine while calls are still to be picked
+	next := conv.Update(InputContractEvents{pbconvo.UserInput_Selection{}})
+	assert.Equal(t, AskContractCalls{}, next())
+
+	seq := conv.Update(InputContractCalls{pbconvo.UserInput_Selection{}})().(loop.SeqMsg)
+	assert.Contains(t, seq[0]().(*pbconvo.SystemOutput).GetMessage().Markdown, "please select at least one event or call")
+	assert.Equal(t, AskContractCalls{}, seq[1]())
+	assert.Nil(t, contract.Calls)
+
+	contract.TrackCalls = false
+	contract.Events = nil
+	seq = conv.Update(InputContractEvents{pbconvo.UserInput_Selection{}})().(loop.SeqMsg)
+	assert.Equal(t, AskContractEvents{}, seq[1]())
+	assert.Nil(t, contract.Events)
+}
+
+func TestContractSetupForm(t *testing.T) {
+	newConvo := func(clientVersion uint32) (*codegen.MsgWrapFactory, codegen.Converser, *Project) {
+		factory := codegen.NewMsgWrapFactory(nil)
+		factory.SetClientVersion(clientVersion)
+		conv := New()
+		conv.SetFactory(factory)
+		p := conv.(*Convo).State
+		p.Name = "my-proj"
+		p.ChainName = "mainnet"
+		p.Contracts = append(p.Contracts, &Contract{})
+		return factory, conv, p
+	}
+	formResponse := func(address, name, initialBlock, track string) *pbconvo.UserInput {
+		text := func(name, value string) *pbconvo.UserInput_FormResponse_Field {
+			return &pbconvo.UserInput_FormResponse_Field{Name: name, Entry: &pbconvo.UserInput_FormResponse_Field_TextInput{
+				TextInput: &pbconvo.UserInput_TextInput{Value: value},
+			}}
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_FormResponse_{FormResponse: &pbconvo.UserInput_FormResponse{
+			Fields: []*pbconvo.UserInput_FormResponse_Field{
+				text("address", address),
+				text("name", name),
+				text("initial_block", initialBlock),
+				{Name: "track", Entry: &pbconvo.UserInput_FormResponse_Field_Selection{
+					Selection: &pbconvo.UserInput_Selection{Value: track},
+				}},
+			},
+		}}}
+	}
+
+	_, conv, _ := newConvo(codegen.ProtocolVersionUpload)
+	assert.Equal(t, AskContractAddress{}, conv.NextStep()())
+
+	factory, conv, p := newConvo(codegen.ProtocolVersionForm)
+	assert.Equal(t, AskContractSetup{}, conv.NextStep()())
+
+	seq := conv.Update(AskContractSetup{})().(loop.SeqMsg)
+	form := seq[1]().(*pbconvo.SystemOutput).GetForm()
+	require.NotNil(t, form)
+	var names []string
+	for _, field := range form.Fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"address", "name", "initial_block", "track"}, names)
+
+	msg, err := factory.DecodeInput(formResponse("0x123", "bayc", "", "events"))
+	require.NoError(t, err)
+	assert.Contains(t, msg.Msg.(codegen.MsgInvalidInput).Reason, "Contract address: Please enter a valid Ethereum address")
+
+	msg, err = factory.DecodeInput(formResponse("0xBC4CA0EDA7647A8AB7C2061C2E118A18A936F13D", "bayc", "12287507", "both"))
+	require.NoError(t, err)
+	require.IsType(t, InputContractSetup{}, msg.Msg)
+
+	assert.Equal(t, FetchContractABI{}, conv.Update(msg.Msg)())
+	contract := p.Contracts[0]
+	assert.Equal(t, "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d", contract.Address)
+	assert.Equal(t, "bayc", contract.Name)
+	require.NotNil(t, contract.InitialBlock)
+	assert.Equal(t, uint64(12287507), *contract.InitialBlock)
+	assert.True(t, contract.TrackEvents)
+	assert.True(t, contract.TrackCalls)
+
+	// an empty initial block is looked up on the explorer, without asking
+	factory, conv, p = newConvo(codegen.ProtocolVersionForm)
+	assert.Equal(t, AskContractSetup{}, conv.NextStep()())
+	conv.Update(AskContractSetup{})().(loop.SeqMsg)[1]()
+	msg, err = factory.DecodeInput(formResponse("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d", "bayc", "", "events"))
+	require.NoError(t, err)
+	assert.Equal(t, FetchContractABI{}, conv.Update(msg.Msg)())
+	assert.Nil(t, p.Contracts[0].InitialBlock)
+	conv.Update(ReturnFetchContractInitialBlock{InitialBlock: 12287507})
+	require.NotNil(t, p.Contracts[0].InitialBlock)
+	assert.Equal(t, uint64(12287507), *p.Contracts[0].InitialBlock)
+
+	// an initial block overflowing uint64 is reported, and asked again
+	factory, conv, p = newConvo(codegen.ProtocolVersionForm)
+	assert.Equal(t, AskContractSetup{}, conv.NextStep()())
+	conv.Update(AskContractSetup{})().(loop.SeqMsg)[1]()
+	msg, err = factory.DecodeInput(formResponse("0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d", "bayc", "99999999999999999999", "events"))
+	require.NoError(t, err)
+	seq = conv.Update(msg.Msg)().(loop.SeqMsg)
+	require.Len(t, seq, 2)
+	assert.Contains(t, seq[0]().(*pbconvo.SystemOutput).GetMessage().GetMarkdown(), `Cannot parse the block number "99999999999999999999"`)
+	assert.Equal(t, FetchContractABI{}, seq[1]())
+	assert.Equal(t, "bayc", p.Contracts[0].Name)
+	assert.Nil(t, p.Contracts[0].InitialBlock)
+	assert.False(t, p.Contracts[0].lookupInitialBlock)
+}
+
+func TestContractSetupFormRelinksFactory(t *testing.T) {
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetClientVersion(codegen.ProtocolVersionForm)
+	conv := New()
+	conv.SetFactory(factory)
+	p := conv.(*Convo).State
+	p.Name = "my-proj"
+	p.ChainName = "mainnet"
+
+	// The factory's address and name were reset from the review, the form is
+	// asked again: the dynamic contract follows the new name.
+	isFactory := true
+	p.Contracts = []*Contract{{BaseContract: BaseContract{Name: "factory"}, TrackFactory: &isFactory}}
+	p.DynamicContracts = []*DynamicContract{{BaseContract: BaseContract{Name: "pool"}, ParentContractName: "factory"}}
+	p.resetContractName(p.Contracts[0])
+	p.Contracts[0].resetAddress()
+	assert.Equal(t, AskContractSetup{}, conv.NextStep()())
+
+	conv.Update(AskContractSetup{})().(loop.SeqMsg)[1]()
+	msg, err := factory.DecodeInput(&pbconvo.UserInput{Entry: &pbconvo.UserInput_FormResponse_{FormResponse: &pbconvo.UserInput_FormResponse{
+		Fields: []*pbconvo.UserInput_FormResponse_Field{
+			{Name: "address", Entry: &pbconvo.UserInput_FormResponse_Field_TextInput{TextInput: &pbconvo.UserInput_TextInput{Value: "0x1f98431c8ad98523631ae4a59f267346ea31f984"}}},
+			{Name: "name", Entry: &pbconvo.UserInput_FormResponse_Field_TextInput{TextInput: &pbconvo.UserInput_TextInput{Value: "uniswap"}}},
+			{Name: "initial_block", Entry: &pbconvo.UserInput_FormResponse_Field_TextInput{TextInput: &pbconvo.UserInput_TextInput{Value: "12369621"}}},
+			{Name: "track", Entry: &pbconvo.UserInput_FormResponse_Field_Selection{Selection: &pbconvo.UserInput_Selection{Value: "events"}}},
+		},
+	}}})
+	require.NoError(t, err)
+	conv.Update(msg.Msg)
+	assert.Equal(t, "uniswap", p.DynamicContracts[0].ParentContractName)
+}
+
+func TestRichOutput(t *testing.T) {
+	preview := func(clientVersion uint32) []*pbconvo.SystemOutput {
+		conv := loadProjectFromState(t, "./testdata/bayc.state.json")
+		factory := codegen.NewMsgWrapFactory(nil)
+		factory.SetClientVersion(clientVersion)
+		conv.SetFactory(factory)
+		contract := conv.State.Contracts[0]
+		contract.abiFetchedInThisSession = true
+
+		res := CmdDecodeABI(contract)().(ReturnRunDecodeContractABI)
+		require.NoError(t, res.Err)
+		seq := conv.Update(res)().(loop.SeqMsg)
+		var out []*pbconvo.SystemOutput
+		for _, msg := range seq[0]().(loop.SeqMsg) {
+			out = append(out, msg().(*pbconvo.SystemOutput))
+		}
+		return out
+	}
+
+	out := preview(codegen.ProtocolVersionRichOutput)
+	require.Len(t, out, 3)
+	events := out[1].GetTable()
+	require.NotNil(t, events)
+	assert.Equal(t, "Events", events.Title)
+	assert.Equal(t, []string{"Message", "Field", "Type"}, events.Columns)
+	assert.Contains(t, events.Rows, &pbconvo.SystemOutput_Table_Row{Cells: []string{"Transfer", "from", "bytes"}})
+	assert.NotNil(t, out[2].GetTable())
+	assert.Regexp(t, `Transfer +from +bytes`, out[1].Humanize(0))
+
+	out = preview(codegen.ProtocolVersionInitial)
+	require.Len(t, out, 3)
+	assert.Contains(t, out[1].GetMessage().Markdown, "| Transfer | from | bytes |")
+
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetClientVersion(codegen.ProtocolVersionRichOutput)
+	tree := factory.NewMsg(nil).FileTree("Generated files", map[string][]byte{
+		"substreams.yaml":      make([]byte, 1500),
+		"src/lib.rs":           make([]byte, 120),
+		"proto/contract.proto": make([]byte, 80),
+	}).Msg
+	require.NotNil(t, tree.GetFileTree())
+	assert.Equal(t, `Generated files
+├── proto/
+│   └── contract.proto (80 B)
+├── src/
+│   └── lib.rs (120 B)
+└── substreams.yaml (1.5 kB)
+3 files, 1.7 kB`, tree.GetFileTree().Text())
+}
+
+func TestActionIDs(t *testing.T) {
+	conv := loadProjectFromState(t, "./testdata/bayc.state.json")
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetGeneratorID("evm-events-calls")
+	conv.SetFactory(factory)
+	contract := conv.State.Contracts[0]
+	res := CmdDecodeABI(contract)().(ReturnRunDecodeContractABI)
+	require.NoError(t, res.Err)
+	contract.Abi = res.Abi
+
+	prompt := conv.Update(AskContractEvents{})().(*pbconvo.SystemOutput)
+	assert.Equal(t, "evm-events-calls.contract_events", prompt.ActionId)
+
+	actions := codegen.Registry["evm-events-calls"].Actions()
+	assert.Contains(t, actions, &pbconvo.DiscoveryResponse_Action{Id: "evm-events-calls.contract_address", Input: "text_input"})
+	assert.Contains(t, actions, &pbconvo.DiscoveryResponse_Action{Id: "evm-events-calls.contract_events", Input: "selection"})
+	assert.Contains(t, actions, &pbconvo.DiscoveryResponse_Action{Id: "evm-events-calls.contract_setup", Input: "form_response"})
+	assert.Contains(t, actions, &pbconvo.DiscoveryResponse_Action{Id: "evm-events-calls.project_name", Input: "text_input"})
+}
+
+func TestPromptPairing(t *testing.T) {
+	factory := codegen.NewMsgWrapFactory(nil)
+	quitErr := func(cmd loop.Cmd) error {
+		quit, ok := cmd().(loop.QuitMsg)
+		require.True(t, ok, "expected the output to be rejected")
+		return quit.Err()
+	}
+
+	msg := factory.NewInput(InputContractIsFactory{}, nil).Confirm("Is it a factory?", "Yes", "No").DefaultAccept().Cmd()()
+	assert.NotNil(t, msg.(*pbconvo.SystemOutput).GetConfirm())
+
+	err := quitErr(factory.NewInput(InputContractIsFactory{}, nil).TextInput("Contract name?", "Submit").Cmd())
+	assert.ErrorIs(t, err, codegen.ErrInvalidOutput)
+	assert.ErrorContains(t, err, "TextInput prompt cannot be answered with evm_events_calls.InputContractIsFactory, it must embed pbconvo.UserInput_TextInput")
+
+	err = quitErr(factory.NewInput(InputContractName{}, nil).TextInput("Contract name?", "Submit").Labels("a", "b").Cmd())
+	assert.ErrorContains(t, err, "Labels cannot be used on a TextInput")
+
+	err = quitErr(factory.NewMsg(nil).ListSelect("Pick one").Cmd())
+	assert.ErrorContains(t, err, "ListSelect prompt has no input to be answered with")
+
+	field := codegen.NewFormField().TextInput("Name", "").SelectMany()
+	err = quitErr(factory.NewInput(InputContractSetup{}, nil).Form("Setup", "Submit").Field("name", field).Cmd())
+	assert.ErrorContains(t, err, `form field "name": SelectMany cannot be used on a TextInput`)
+}
+
+func TestFetchCancellation(t *testing.T) {
+	conv := loadProjectFromState(t, "./testdata/bayc.state.json")
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+	fetch, ok := conv.Update(FetchContractABI{})().(loop.WithContextMsg)
+	require.True(t, ok, "the ABI must be fetched with a context")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	res := fetch.Run(ctx).(ReturnFetchContractABI)
+	assert.ErrorIs(t, res.err, context.Canceled)
+
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetCommandTimeout(10 * time.Millisecond)
+	factory.SetupLoop(func(msg loop.Msg) loop.Cmd {
+		return loop.Quit(msg.(error))
+	})
+	err := factory.Run(context.Background(), loop.WithContext(func(ctx context.Context) loop.Msg {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestConversationTranscript(t *testing.T) {
+	abi, err := os.ReadFile("testdata/bayc_contract.abi.json")
+	require.NoError(t, err)
+
+	driver, err := codegen.NewDriver("evm-events-calls", codegen.ProtocolVersionInitial)
+	require.NoError(t, err)
+	driver.Stub(FetchContractABI{}, func(loop.Msg) loop.Msg {
+		return ReturnFetchContractABI{abi: string(abi)}
+	})
+	driver.Stub(FetchContractInitialBlock{}, func(loop.Msg) loop.Msg {
+		return ReturnFetchContractInitialBlock{InitialBlock: 12287507}
+	})
+
+	require.NoError(t, driver.Start(""))
+	require.NoError(t, driver.Answer(
+		"My Project",
+		"my_proj",
+		"mainnet",
+		"0xbc4ca0eda7647a8ab7c2061c2e2ad14b52b3e8c3",
+		"yes",
+		"12287507",
+		"bayc",
+		"events",
+		"Approval,Transfer",
+		"no",
+		"no",
+	))
+
+	done, err := driver.Done()
+	assert.True(t, done)
+	assert.NoError(t, err)
+	assert.Contains(t, driver.ProjectFiles(), "substreams.yaml")
+	codegentest.AssertGolden(t, "testdata/bayc.transcript.golden", driver.Transcript())
+}
+
+func TestHydrateStateSavedBeforeSelection(t *testing.T) {
+	cnt, err := os.ReadFile("../tests/evm-events-calls/generator.json")
+	require.NoError(t, err)
+	var file codegen.GeneratorFile
+	require.NoError(t, json.Unmarshal(cnt, &file))
+	require.NotContains(t, string(file.State), `"events"`)
+
+	driver, err := codegen.NewDriver("evm-events-calls", codegen.ProtocolVersionLatest)
+	require.NoError(t, err)
+	require.NoError(t, driver.Start(string(file.State)))
+
+	// Complete before events and calls could be picked, it generates them all
+	assert.Equal(t, "evm-events-calls.review", driver.Prompt().ActionId)
+	require.NoError(t, driver.Answer(codegen.ReviewGenerate))
+	assert.Contains(t, string(driver.ProjectFiles()["src/lib.rs"]), "fn map_events")
+	assert.Contains(t, string(driver.ProjectFiles()["src/lib.rs"]), "fn map_calls")
+}
+
+func TestRunScriptedUploadAndForm(t *testing.T) {
+	answersPath := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, os.WriteFile(answersPath, []byte(`
+generator: evm-events-calls
+protocol_version: 3
+answers:
+  project_name: my_project
+  chain_name: amoy
+  contract_setup:
+    address: "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"
+    name: bayc
+    initial_block: "12287507"
+  contract_abi_upload: bayc_contract.abi.json
+  contract_is_factory: no
+  add_contract: no
+`), 0o644))
+	answers, err := codegen.LoadAnswers(answersPath)
+	require.NoError(t, err)
+	answers.LocalFilesRoot = "testdata"
+
+	var events []string
+	files, err := codegen.RunScripted(context.Background(), answers, func(event string) {
+		events = append(events, event)
+	})
+	require.NoError(t, err, "events:\n%s", strings.Join(events, "\n"))
+	assert.Contains(t, files, "substreams.yaml")
+	abi, err := os.ReadFile("testdata/bayc_contract.abi.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, string(abi), string(files["abi/bayc_contract.abi.json"]))
+	assert.Contains(t, string(files["substreams.yaml"]), "initialBlock: 12287507")
+	assert.Contains(t, strings.Join(events, "\n"), "[Uploaded file] bayc_contract.abi.json")
+	assert.Contains(t, strings.Join(events, "\n"), "[Form] address=0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d, name=bayc, initial_block=12287507")
+
+	answers, err = codegen.LoadAnswers(answersPath)
+	require.NoError(t, err)
+	answers.Answers["contract_setup"][0].Fields["adress"] = "0xbc4ca0eda7647a8ab7c2061c2e118a18a936f13d"
+	_, err = codegen.RunScripted(context.Background(), answers, nil)
+	assert.ErrorContains(t, err, `no field "adress" in the form`)
+}
@@ -1,6 +1,7 @@
 package evm_events_calls
 
 import (
+	"context"
 	"embed"
 	"fmt"
 
@@ -12,8 +13,8 @@ import (
 //go:embed templates/*
 var templatesFS embed.FS
 
-func (p *Project) Generate() codegen.ReturnGenerate {
-	res := codegen.GenerateTemplateTree(p, templatesFS, map[string]string{
+func (p *Project) Generate(ctx context.Context) codegen.ReturnGenerate {
+	res := codegen.GenerateTemplateTree(ctx, p, templatesFS, map[string]string{
 		"proto/contract.proto.gotmpl":   "proto/contract.proto",
 		"src/abi/mod.rs.gotmpl":         "src/abi/mod.rs",
 		"src/pb/mod.rs.gotmpl":          "src/pb/mod.rs",
@@ -1,6 +1,7 @@
 package evm_events_calls
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"testing"
@@ -98,7 +99,7 @@ func Test_Generate(t *testing.T) {
 				}
 			}
 
-			res := p.Generate()
+			res := p.Generate(context.Background())
 			require.NoError(t, res.Err)
 			assert.NotEmpty(t, len(res.ProjectFiles))
 
@@ -177,7 +178,7 @@ func TestUniFactory(t *testing.T) {
 		contract.Abi = res.Abi
 	}
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	require.NoError(t, res.Err)
 	assert.NotEmpty(t, len(res.ProjectFiles))
 
@@ -201,7 +202,7 @@ func TestBaycSQL(t *testing.T) {
 		contract.Abi = res.Abi
 	}
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	require.NoError(t, res.Err)
 	assert.NotEmpty(t, len(res.ProjectFiles))
 }
@@ -226,7 +227,7 @@ func Test_Uniswapv3riggersDynamicDatasources(t *testing.T) {
 		contract.parentContract = p.Contracts[0]
 	}
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	require.NoError(t, res.Err)
 	assert.NotEmpty(t, len(res.ProjectFiles))
 
@@ -247,7 +248,7 @@ func Test_BaycTriggers(t *testing.T) {
 		contract.Abi = res.Abi
 	}
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	require.NoError(t, res.Err)
 	assert.NotEmpty(t, len(res.ProjectFiles))
 
@@ -7,12 +7,15 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/codemodus/kace"
 	"github.com/golang-cz/textcase"
 	"github.com/huandu/xstrings"
 	"github.com/streamingfast/eth-go"
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Project struct {
@@ -41,11 +44,52 @@ func contractNames(contracts []*Contract) (out []string) {
 	return
 }
 
+// Clone deep-copies the project, for going back to restore it along with what is
+// not saved: the ABIs fetched in this session, the pending lookups and the links
+// from the dynamic contracts to their factory.
+func (p *Project) Clone() *Project {
+	out := *p
+	clones := make(map[*Contract]*Contract, len(p.Contracts))
+	out.Contracts = make([]*Contract, len(p.Contracts))
+	for i, contract := range p.Contracts {
+		clone := *contract
+		clone.BaseContract = contract.BaseContract.clone()
+		clone.InitialBlock = clonePtr(contract.InitialBlock)
+		clone.TrackFactory = clonePtr(contract.TrackFactory)
+		clone.FactoryCreationEventFieldIdx = clonePtr(contract.FactoryCreationEventFieldIdx)
+		clones[contract] = &clone
+		out.Contracts[i] = &clone
+	}
+	out.DynamicContracts = make([]*DynamicContract, len(p.DynamicContracts))
+	for i, dynContract := range p.DynamicContracts {
+		clone := *dynContract
+		clone.BaseContract = dynContract.BaseContract.clone()
+		clone.parentContract = clones[dynContract.parentContract]
+		out.DynamicContracts[i] = &clone
+	}
+	return &out
+}
+
+func clonePtr[T any](in *T) *T {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
 func (p *Project) ChainConfig() *ChainConfig { return ChainConfigByID[p.ChainName] }
 
 func (p *Project) ModuleName() string { return strings.ReplaceAll(p.Name, "-", "_") }
 func (p *Project) KebabName() string  { return strings.ReplaceAll(p.Name, "_", "-") }
 
+func (p *Project) TraceAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		codegen.ChainAttribute.String(p.ChainName),
+		codegen.ContractCountAttribute.Int(len(p.Contracts)),
+	}
+}
+
 func (p *Project) GetContractByName(contractName string) *Contract {
 	for _, contract := range p.Contracts {
 		if contract.Name == contractName {
@@ -71,6 +115,40 @@ func (p *Project) dynamicContractOf(contractName string) (out *DynamicContract)
 	return
 }
 
+func (p *Project) removeDynamicContractOf(contractName string) {
+	for i, dynContract := range p.DynamicContracts {
+		if dynContract.ParentContractName == contractName {
+			p.DynamicContracts = append(p.DynamicContracts[:i], p.DynamicContracts[i+1:]...)
+			return
+		}
+	}
+}
+
+// resetContractName clears the name of the contract, keeping a link to its
+// dynamic contract so relinkDynamicContract() can follow the new name.
+func (p *Project) resetContractName(contract *Contract) {
+	for _, dynContract := range p.DynamicContracts {
+		if dynContract.ParentContractName == contract.Name {
+			dynContract.parentContract = contract
+		}
+	}
+	contract.Name = ""
+}
+
+// relinkDynamicContract gives the new name of the contract to its dynamic
+// contract. The link set by resetContractName() is not part of the saved state:
+// when it is lost (the state was hydrated), a factory takes the dynamic contract
+// left without a parent, as only the contract being renamed can have left it.
+func (p *Project) relinkDynamicContract(contract *Contract) {
+	isFactory := contract.TrackFactory != nil && *contract.TrackFactory
+	for _, dynContract := range p.DynamicContracts {
+		if dynContract.parentContract == contract || (isFactory && p.GetContractByName(dynContract.ParentContractName) == nil) {
+			dynContract.ParentContractName = contract.Name
+			dynContract.parentContract = contract
+		}
+	}
+}
+
 func isValidChainName(input string) bool {
 	return ChainConfigByID[input] != nil
 }
@@ -177,11 +255,28 @@ type BaseContract struct {
 	TrackCalls  bool            `json:"trackCalls"`
 	RawABI      json.RawMessage `json:"rawAbi,omitempty"`
 
+	// Events and Calls are the IDs of the events and calls picked by the user
+	// for generation, everything is generated when nil.
+	Events []string `json:"events"`
+	Calls  []string `json:"calls"`
+	// SelectEventsCalls is set along with TrackEvents and TrackCalls, for the
+	// Events and Calls to be asked next. The states saved before they could be
+	// picked don't have it: they stay complete, generating everything.
+	SelectEventsCalls bool `json:"selectEventsCalls,omitempty"`
+
 	abiFetchedInThisSession bool
 	Abi                     *ABI
 	emptyABI                bool
 }
 
+// clone copies the contract, sharing its decoded ABI which is never modified.
+func (c BaseContract) clone() BaseContract {
+	c.RawABI = slices.Clone(c.RawABI)
+	c.Events = slices.Clone(c.Events)
+	c.Calls = slices.Clone(c.Calls)
+	return c
+}
+
 func (c *BaseContract) Identifier() string { return c.Name }
 func (c *BaseContract) IdentifierSnakeCase() string {
 	return xstrings.ToSnakeCase(c.Name)
@@ -202,7 +297,35 @@ func (c *BaseContract) EventFields(event string) ([]*eth.LogParameter, error) {
 	return eventDef.Parameters, nil
 }
 
+// generatesNothing reports whether no event and no call of the contract are
+// selected, the ones still to be picked counting as selected.
+func (c *BaseContract) generatesNothing() bool {
+	return !(c.TrackEvents && len(c.EventModels()) != 0) && !(c.TrackCalls && len(c.CallModels()) != 0)
+}
+
+// CallModels returns the models of the selected calls.
 func (c *BaseContract) CallModels() []codegenCall {
+	calls := c.allCallModels()
+	if c.Calls == nil {
+		return calls
+	}
+	return slices.DeleteFunc(calls, func(call codegenCall) bool {
+		return !slices.Contains(c.Calls, call.ID())
+	})
+}
+
+// EventModels returns the models of the selected events.
+func (c *BaseContract) EventModels() []codegenEvent {
+	evts := c.allEventModels()
+	if c.Events == nil {
+		return evts
+	}
+	return slices.DeleteFunc(evts, func(evt codegenEvent) bool {
+		return !slices.Contains(c.Events, evt.ID())
+	})
+}
+
+func (c *BaseContract) allCallModels() []codegenCall {
 	calls, err := c.Abi.BuildCallModels()
 	if err != nil {
 		panic(err)
@@ -210,7 +333,7 @@ func (c *BaseContract) CallModels() []codegenCall {
 	return calls
 }
 
-func (c *BaseContract) EventModels() []codegenEvent {
+func (c *BaseContract) allEventModels() []codegenEvent {
 	evts, err := c.Abi.BuildEventModels()
 	if err != nil {
 		panic(err)
@@ -226,12 +349,36 @@ type Contract struct {
 	TrackFactory                 *bool  `json:"trackFactory"`
 	FactoryCreationEvent         string `json:"factoryCreationEvent"`
 	FactoryCreationEventFieldIdx *int64 `json:"factoryCreationEventFieldIdx"`
+
+	// lookupInitialBlock is set when the initial block was left empty in the
+	// setup form, the one found on the explorer is then used without asking.
+	lookupInitialBlock bool
+}
+
+// resetAddress clears the address, and everything fetched or chosen from it.
+func (c *Contract) resetAddress() {
+	c.Address = ""
+	c.RawABI = nil
+	c.Abi = nil
+	c.abiFetchedInThisSession = false
+	c.emptyABI = false
+	c.InitialBlock = nil
+	c.Events = nil
+	c.Calls = nil
+	c.FactoryCreationEvent = ""
+	c.FactoryCreationEventFieldIdx = nil
+}
+
+func (c *Contract) resetFactory() {
+	c.TrackFactory = nil
+	c.FactoryCreationEvent = ""
+	c.FactoryCreationEventFieldIdx = nil
 }
 
 func (c *Contract) PlainAddress() string { return strings.TrimPrefix(c.Address, "0x") }
 
 func (c *Contract) FactoryCreationEventName() string {
-	for _, ev := range c.EventModels() {
+	for _, ev := range c.allEventModels() {
 		if ev.Proto.MessageHash == c.FactoryCreationEvent {
 			return ev.Proto.MessageName
 		}
@@ -240,7 +387,7 @@ func (c *Contract) FactoryCreationEventName() string {
 }
 
 func (c *Contract) FactoryCreationEventFieldName() string {
-	for _, ev := range c.EventModels() {
+	for _, ev := range c.allEventModels() {
 		if ev.Proto.MessageHash == c.FactoryCreationEvent {
 			return ev.Proto.Fields[int(*c.FactoryCreationEventFieldIdx)].Name
 		}
@@ -248,16 +395,16 @@ func (c *Contract) FactoryCreationEventFieldName() string {
 	panic("not found")
 }
 
-func (c *Contract) FetchABI(chainConfig *ChainConfig) (abi string, err error) {
-	a, err := getContractABIFollowingProxy(context.Background(), c.Address, chainConfig)
+func (c *Contract) FetchABI(ctx context.Context, chainConfig *ChainConfig) (abi string, err error) {
+	a, err := getContractABIFollowingProxy(ctx, c.Address, chainConfig)
 	if err != nil {
 		return "", err
 	}
 	return a.raw, nil
 }
 
-func (c *Contract) FetchInitialBlock(chainConfig *ChainConfig) (initialBlock uint64, err error) {
-	return getContractInitialBlock(context.Background(), chainConfig, c.Address)
+func (c *Contract) FetchInitialBlock(ctx context.Context, chainConfig *ChainConfig) (initialBlock uint64, err error) {
+	return getContractInitialBlock(ctx, chainConfig, c.Address)
 }
 
 // That's a contract that is _created by a Factory_. It doesn't have a start block because it
@@ -278,16 +425,23 @@ func (d DynamicContract) FactoryInitialBlock() uint64 {
 func (d DynamicContract) ParentContract() *Contract   { return d.parentContract }
 func (d DynamicContract) Identifier() string          { return d.Name }
 func (d DynamicContract) IdentifierSnakeCase() string { return kace.Snake(d.Name) }
-func (d DynamicContract) FetchABI(chainConfig *ChainConfig) (abi string, err error) {
-	a, err := getContractABIFollowingProxy(context.Background(), d.referenceContractAddress, chainConfig)
+func (d DynamicContract) FetchABI(ctx context.Context, chainConfig *ChainConfig) (abi string, err error) {
+	a, err := getContractABIFollowingProxy(ctx, d.referenceContractAddress, chainConfig)
 	if err != nil {
 		return "", err
 	}
 	return a.raw, nil
 }
 
+var (
+	// contractAddressRegexp and contractNameRegexp validate the addresses and
+	// names entered for the contracts.
+	contractAddressRegexp = regexp.MustCompile("^0x[a-fA-F0-9]{40}$")
+	contractNameRegexp    = regexp.MustCompile(`^([a-z][a-z0-9_]{0,63})$`)
+)
+
 func validateContractName(p *Project, name string) error {
-	if !regexp.MustCompile(`^([a-z][a-z0-9_]{0,63})$`).MatchString(name) {
+	if !contractNameRegexp.MatchString(name) {
 		return fmt.Errorf("contract name %s is invalid, it must match the regex ^([a-z][a-z0-9_]{0,63})$", name)
 	}
 
@@ -325,6 +479,34 @@ func validateContractAddress(p *Project, address string) error {
 	return nil
 }
 
+// resetUnvalidatedFields clears what a state not signed by this server holds
+// without having been validated by it: the fields failing their validation are
+// asked again, and the ABIs, initial blocks and factory creation events, looked
+// up on explorers or checked against the ABIs, are fetched or asked again.
+func (p *Project) resetUnvalidatedFields() {
+	if !codegen.ValidProjectName(p.Name) {
+		p.Name = ""
+	}
+	for _, contract := range p.Contracts {
+		if !contractAddressRegexp.MatchString(contract.Address) {
+			contract.resetAddress()
+		}
+		if !contractNameRegexp.MatchString(contract.Name) {
+			p.resetContractName(contract)
+		}
+		contract.RawABI = nil
+		contract.InitialBlock = nil
+		contract.FactoryCreationEvent = ""
+		contract.FactoryCreationEventFieldIdx = nil
+	}
+	for _, dynamicContract := range p.DynamicContracts {
+		if !contractNameRegexp.MatchString(dynamicContract.Name) {
+			dynamicContract.Name = ""
+		}
+		dynamicContract.RawABI = nil
+	}
+}
+
 func validateIncomingState(p *Project) error {
 	uniqueContractNames := map[string]struct{}{}
 	uniqueContractAddresses := map[string]struct{}{}
@@ -17,6 +17,11 @@ type StartFirstContract struct{} // Start asking for contract inputs
 
 type MsgContractSwitch struct{}
 
+// AskContractSetup asks for the address, name, initial block and what to track
+// of a new contract at once, for clients supporting forms.
+type AskContractSetup struct{}
+type InputContractSetup struct{ pbconvo.UserInput_FormResponse }
+
 type AskContractAddress struct{}
 type MsgInvalidContractAddress struct {
 	Err error
@@ -45,6 +50,18 @@ type InputContractTrackWhat struct{ pbconvo.UserInput_Selection }
 type AskDynamicContractTrackWhat struct{}
 type InputDynamicContractTrackWhat struct{ pbconvo.UserInput_Selection }
 
+type AskContractEvents struct{}
+type InputContractEvents struct{ pbconvo.UserInput_Selection }
+
+type AskContractCalls struct{}
+type InputContractCalls struct{ pbconvo.UserInput_Selection }
+
+type AskDynamicContractEvents struct{}
+type InputDynamicContractEvents struct{ pbconvo.UserInput_Selection }
+
+type AskDynamicContractCalls struct{}
+type InputDynamicContractCalls struct{ pbconvo.UserInput_Selection }
+
 type FetchContractABI struct{}
 type ReturnFetchContractABI struct {
 	abi string
@@ -59,9 +76,11 @@ type ReturnFetchDynamicContractABI struct {
 
 type AskContractABI struct{}
 type InputContractABI struct{ pbconvo.UserInput_TextInput }
+type InputContractABIUpload struct{ pbconvo.UserInput_Upload }
 
 type AskDynamicContractABI struct{}
 type InputDynamicContractABI struct{ pbconvo.UserInput_TextInput }
+type InputDynamicContractABIUpload struct{ pbconvo.UserInput_Upload }
 
 type RunDecodeContractABI struct{}
 type ReturnRunDecodeContractABI struct {
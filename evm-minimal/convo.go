@@ -20,6 +20,7 @@ func init() {
 		`Supported networks: `+strings.Join(supportedChains, ", "),
 		codegen.ConversationFactory(New),
 		83,
+		codegen.InputChainName{},
 	)
 }
 
@@ -47,13 +48,27 @@ func (c *Convo) NextStep() (out loop.Cmd) {
 		return loop.Seq(cmd(codegen.MsgInvalidChainName{}), cmd(codegen.AskChainName{}))
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
 }
 
 func isValidChainName(input string) bool {
 	return ChainConfigByID[input] != nil
 }
 
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	var chainDisplayName string
+	if conf := ChainConfigByID[p.ChainName]; conf != nil {
+		chainDisplayName = conf.DisplayName
+	}
+
+	return []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+		codegen.ChainNameField(&p.ChainName, chainDisplayName),
+	}
+}
+
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 	switch msg := msg.(type) {
 	case codegen.MsgStart:
@@ -63,7 +78,9 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				return loop.Quit(fmt.Errorf(`something went wrong, here's an error message to share with our devs (%s); we've notified them already`, err))
 			}
 
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -102,6 +119,13 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		}
 		return c.NextStep()
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
@@ -1,11 +1,18 @@
 package ethminimal
 
 import (
+	"context"
+	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	codegen "github.com/streamingfast/substreams-codegen"
+	"github.com/streamingfast/substreams-codegen/codegentest"
 	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConvoNextStep(t *testing.T) {
@@ -21,7 +28,276 @@ func TestConvoNextStep(t *testing.T) {
 	assert.Equal(t, codegen.AskChainName{}, next())
 	p.ChainName = "arbitrum"
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	assert.NoError(t, res.Err)
 	assert.NotEmpty(t, res.ProjectFiles)
 }
+
+func TestGenerateFromState(t *testing.T) {
+	files, err := codegen.GenerateFromState(context.Background(), "evm-minimal", `{"name":"my_project","chainName":"mainnet"}`)
+	require.NoError(t, err)
+	assert.Contains(t, files, "substreams.yaml")
+
+	_, err = codegen.GenerateFromState(context.Background(), "evm-minimal", `{"name":"my_project"}`)
+	var incompleteErr *codegen.IncompleteStateError
+	require.ErrorAs(t, err, &incompleteErr)
+	assert.Equal(t, []string{"evm-minimal.chain_name (Please select the chain)"}, incompleteErr.Missing)
+
+	_, err = codegen.GenerateFromState(context.Background(), "evm-minimal", `{}`)
+	require.ErrorAs(t, err, &incompleteErr)
+	assert.Equal(t, []string{
+		"evm-minimal.project_name (Please enter the project name)",
+		"evm-minimal.chain_name (Please select the chain)",
+	}, incompleteErr.Missing)
+}
+
+func TestWriteProjectFiles(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	files := map[string][]byte{"substreams.yaml": []byte("specVersion: v0.1.0"), "src/lib.rs": []byte("")}
+	require.NoError(t, codegen.WriteProjectFiles(out, files))
+	assert.FileExists(t, filepath.Join(out, "src", "lib.rs"))
+
+	for i, name := range []string{"../.bashrc", "src/../../.bashrc", "/tmp/.bashrc"} {
+		out := filepath.Join(dir, fmt.Sprintf("out-%d", i))
+		err := codegen.WriteProjectFiles(out, map[string][]byte{"substreams.yaml": nil, name: []byte("evil")})
+		assert.ErrorContains(t, err, "refusing to write", name)
+		assert.NoDirExists(t, out, name)
+	}
+	assert.NoFileExists(t, filepath.Join(dir, ".bashrc"))
+}
+
+func TestRunScripted(t *testing.T) {
+	files, err := codegen.RunScripted(context.Background(), &codegen.Answers{
+		Generator: "evm-minimal",
+		Answers: map[string]codegen.AnswerValues{
+			"InputProjectName":       {{Value: "my_project"}},
+			"codegen.InputChainName": {{Value: "mainnet"}},
+		},
+	}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, files, "substreams.yaml")
+
+	_, err = codegen.RunScripted(context.Background(), &codegen.Answers{
+		Generator: "evm-minimal",
+		Answers: map[string]codegen.AnswerValues{
+			"InputProjectName": {{Value: "my_project"}},
+		},
+	}, nil)
+	var unansweredErr *codegen.UnansweredPromptError
+	require.ErrorAs(t, err, &unansweredErr)
+	assert.Equal(t, "Please select the chain", unansweredErr.Prompt)
+}
+
+func TestGoBackFromReview(t *testing.T) {
+	conv := New().(*Convo)
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetClientVersion(codegen.ProtocolVersionReview)
+	conv.SetFactory(factory)
+	conv.State.Name = "my_project"
+	conv.State.ChainName = "mainnet"
+
+	// Accepting the review leaves the serialized state unchanged
+	conv.PushSnapshot()
+	conv.Update(codegen.InputReview{UserInput_Selection: pbconvo.UserInput_Selection{Value: codegen.ReviewGenerate}})
+	assert.True(t, conv.Reviewed())
+
+	require.True(t, conv.PopSnapshot())
+	assert.False(t, conv.Reviewed())
+	assert.Equal(t, codegen.AskReview{}, conv.NextStep()())
+	assert.False(t, conv.PopSnapshot())
+}
+
+func TestConvoReconnect(t *testing.T) {
+	conv := New()
+	conv.SetFactory(codegen.NewMsgWrapFactory(nil))
+
+	next := conv.Update(codegen.MsgStart{UserInput_Start: pbconvo.UserInput_Start{
+		Hydrate: &pbconvo.UserInput_Hydrate{SavedState: `{"name":"my_project"}`},
+	}})
+	seq := next().(loop.SeqMsg)
+	assert.Equal(t, "Ok, I reloaded your state.", seq[0]().(*pbconvo.SystemOutput).GetMessage().GetMarkdown())
+
+	next = conv.Update(codegen.MsgStart{UserInput_Start: pbconvo.UserInput_Start{
+		Hydrate: &pbconvo.UserInput_Hydrate{SavedState: `{"name":"my_project"}`, LastMsgId: 7},
+	}})
+	seq = next().(loop.SeqMsg)
+	assert.Nil(t, seq[0])
+	assert.Equal(t, codegen.AskChainName{}, seq[1]())
+}
+
+func TestInputValidation(t *testing.T) {
+	conv := New()
+	factory := codegen.NewMsgWrapFactory(nil)
+	conv.SetFactory(factory)
+
+	prompt := conv.Update(codegen.AskProjectName{})().(*pbconvo.SystemOutput)
+	msg, err := factory.DecodeInput(&pbconvo.UserInput{Entry: &pbconvo.UserInput_TextInput_{
+		TextInput: &pbconvo.UserInput_TextInput{Value: "My Project!"},
+	}})
+	require.NoError(t, err)
+	invalid, ok := msg.Msg.(codegen.MsgInvalidInput)
+	require.True(t, ok, "got %T", msg.Msg)
+	assert.Equal(t, prompt.GetTextInput().ValidationErrorMessage, invalid.Reason)
+
+	seq := codegen.CmdInvalidInput(factory, invalid)().(loop.SeqMsg)
+	assert.Equal(t, invalid.Reason, seq[0]().(*pbconvo.SystemOutput).GetMessage().GetMarkdown())
+	assert.Equal(t, prompt.GetTextInput().Prompt, seq[1]().(*pbconvo.SystemOutput).GetTextInput().GetPrompt())
+
+	msg, err = factory.DecodeInput(&pbconvo.UserInput{Entry: &pbconvo.UserInput_TextInput_{
+		TextInput: &pbconvo.UserInput_TextInput{Value: "my_project"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "my_project", msg.Msg.(codegen.InputProjectName).Value)
+
+	_, err = codegen.RunScripted(context.Background(), &codegen.Answers{
+		Generator: "evm-minimal",
+		Answers: map[string]codegen.AnswerValues{
+			"InputProjectName":       {{Value: "my_project"}},
+			"codegen.InputChainName": {{Value: "not-a-chain"}},
+		},
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"not-a-chain" is not one of the choices`)
+}
+
+func TestConversationTranscript(t *testing.T) {
+	driver, err := codegen.NewDriver("evm-minimal", codegen.ProtocolVersionLatest)
+	require.NoError(t, err)
+
+	require.NoError(t, driver.Start(""))
+	require.NoError(t, driver.Answer("my_project", "arbitrum", "change_0"))
+	require.NoError(t, driver.AnswerInput(&pbconvo.UserInput{Entry: &pbconvo.UserInput_Back_{Back: &pbconvo.UserInput_Back{}}}))
+	require.NoError(t, driver.Answer("generate"))
+
+	done, err := driver.Done()
+	assert.True(t, done)
+	assert.NoError(t, err)
+	assert.Contains(t, driver.ProjectFiles(), "substreams.yaml")
+	codegentest.AssertGolden(t, "testdata/conversation.transcript.golden", driver.Transcript())
+}
+
+// recordSession runs the conversation with the inputs, and records it like
+// the server does.
+func recordSession(t *testing.T, inputs []*pbconvo.UserInput) *codegen.Session {
+	driver, err := codegen.NewDriver("evm-minimal", codegen.ProtocolVersionLatest)
+	require.NoError(t, err)
+
+	session := codegen.NewSession("evm-minimal")
+	record := func(answer func() error) {
+		seen := len(driver.Outputs())
+		require.NoError(t, answer())
+		for _, output := range driver.Outputs()[seen:] {
+			session.AddOutput(output)
+		}
+	}
+	record(func() error {
+		start := inputs[0].GetStart()
+		session.Start(inputs[0], false, false)
+		return driver.StartWith(start, false)
+	})
+	for _, input := range inputs[1:] {
+		record(func() error {
+			incoming, err := driver.Factory.DecodeInput(input)
+			require.NoError(t, err)
+			session.AddInput(incoming)
+			return driver.AnswerInput(input)
+		})
+	}
+	done, err := driver.Done()
+	if done {
+		session.End(err, false)
+	}
+	return session
+}
+
+func TestReplaySession(t *testing.T) {
+	session := recordSession(t, []*pbconvo.UserInput{
+		{Entry: &pbconvo.UserInput_Start_{Start: &pbconvo.UserInput_Start{GeneratorId: "evm-minimal", Version: codegen.ProtocolVersionLatest}}},
+		{Entry: &pbconvo.UserInput_TextInput_{TextInput: &pbconvo.UserInput_TextInput{Value: "My Project"}}},
+		{Entry: &pbconvo.UserInput_TextInput_{TextInput: &pbconvo.UserInput_TextInput{Value: "my_project"}}},
+		{Entry: &pbconvo.UserInput_Selection_{Selection: &pbconvo.UserInput_Selection{Value: "arbitrum"}}},
+		{Entry: &pbconvo.UserInput_Selection_{Selection: &pbconvo.UserInput_Selection{Value: "change_0"}}},
+		{Entry: &pbconvo.UserInput_Back_{Back: &pbconvo.UserInput_Back{}}},
+		{Entry: &pbconvo.UserInput_Selection_{Selection: &pbconvo.UserInput_Selection{Value: "generate"}}},
+	})
+
+	cnt, err := session.JSONLines()
+	require.NoError(t, err)
+	saved, err := codegen.ParseSession(cnt)
+	require.NoError(t, err)
+	require.Len(t, saved.Records, len(session.Records))
+	assert.Equal(t, session.ID, saved.ID)
+	assert.Equal(t, session.State(), saved.State())
+	assert.Equal(t, session.Text(), saved.Text())
+
+	var inputs []string
+	for _, record := range saved.Records {
+		if record.Direction == codegen.SessionIn {
+			inputs = append(inputs, fmt.Sprintf("%s %s %q %q", record.Kind, record.ActionID, record.Value, record.Invalid))
+		}
+	}
+	assert.Equal(t, []string{
+		`start  "" ""`,
+		`text_input evm-minimal.project_name "My Project" "The project name must be a valid identifier with only lowercase letters, numbers and underscores, up to 64 characters."`,
+		`text_input evm-minimal.project_name "my_project" ""`,
+		`selection evm-minimal.chain_name "arbitrum" ""`,
+		`selection evm-minimal.review "change_0" ""`,
+		`back evm-minimal.project_name "" ""`,
+		`selection evm-minimal.review "generate" ""`,
+	}, inputs)
+	assert.Equal(t, codegen.SessionCompleted, saved.EndRecord().Outcome)
+
+	diff, err := codegen.ReplaySession(context.Background(), saved, time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+
+	// The answer to the chain prompt now selects another chain
+	for _, record := range saved.Records {
+		if record.Input.GetSelection().GetValue() == "arbitrum" {
+			record.Input.GetSelection().Value = "mainnet"
+		}
+	}
+	diff, err = codegen.ReplaySession(context.Background(), saved, time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, 3, diff.Step)
+	assert.Equal(t, `   0┃ Got it, will be using chain "Arbitrum"`, diff.Expected[0])
+	assert.Equal(t, `   0┃ Got it, will be using chain "Ethereum Mainnet"`, diff.Got[0])
+}
+
+func TestSessionEnd(t *testing.T) {
+	session := codegen.NewSession("evm-minimal")
+	session.Start(&pbconvo.UserInput{Entry: &pbconvo.UserInput_Start_{Start: &pbconvo.UserInput_Start{GeneratorId: "evm-minimal"}}}, true, false)
+	end := session.End(fmt.Errorf("conversation error: %w", &loop.PanicError{Value: "boom", Stack: []byte("stack")}), false)
+
+	assert.Equal(t, codegen.SessionError, end.Outcome)
+	assert.Equal(t, []string{"conversation error: panic: boom", "panic: boom"}, end.Errors)
+	assert.Equal(t, "stack", end.Stack)
+	assert.Contains(t, session.Text(), "[Start, hydrate: false, unsigned: true, reconnecting: false] evm-minimal")
+	assert.Contains(t, session.Text(), `ERROR "conversation error: panic: boom" AFTER 0 seconds`)
+
+	session = codegen.NewSession("evm-minimal")
+	session.Start(&pbconvo.UserInput{Entry: &pbconvo.UserInput_Start_{Start: &pbconvo.UserInput_Start{GeneratorId: "evm-minimal"}}}, false, false)
+	assert.Equal(t, codegen.SessionAbandoned, session.End(context.Canceled, true).Outcome)
+}
+
+func TestSessionIdentity(t *testing.T) {
+	session := codegen.NewSession("evm-minimal")
+	session.Identity = &codegen.Identity{Subject: "alice", Tenant: "partner-a", Env: map[string]string{"CODEGEN_MAINNET_API_KEY": "secret"}}
+	session.Start(&pbconvo.UserInput{Entry: &pbconvo.UserInput_Start_{Start: &pbconvo.UserInput_Start{GeneratorId: "evm-minimal"}}}, false, false)
+	session.End(nil, false)
+
+	cnt, err := session.JSONLines()
+	require.NoError(t, err)
+	assert.NotContains(t, string(cnt), "secret")
+	assert.Contains(t, session.Text(), `evm-minimal (as alice, tenant: "partner-a")`)
+
+	parsed, err := codegen.ParseSession(cnt)
+	require.NoError(t, err)
+	assert.Equal(t, &codegen.Identity{Subject: "alice", Tenant: "partner-a"}, parsed.Identity)
+	for _, record := range parsed.Records {
+		assert.Equal(t, "alice", record.Subject)
+		assert.Equal(t, "partner-a", record.Tenant)
+	}
+}
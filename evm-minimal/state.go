@@ -2,6 +2,9 @@ package ethminimal
 
 import (
 	"strings"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Project struct {
@@ -15,3 +18,7 @@ func (p *Project) ChainConfig() *ChainConfig { return ChainConfigByID[p.ChainNam
 
 func (p *Project) ModuleName() string { return strings.ReplaceAll(p.Name, "-", "_") }
 func (p *Project) KebabName() string  { return strings.ReplaceAll(p.Name, "_", "-") }
+
+func (p *Project) TraceAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{codegen.ChainAttribute.String(p.ChainName)}
+}
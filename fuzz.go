@@ -0,0 +1,309 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+const (
+	// fuzzDefaultRuns is the number of conversations run per generator when
+	// FuzzOptions.Runs is not set.
+	fuzzDefaultRuns = 50
+	// fuzzDefaultMaxAnswers bounds the answers given to a conversation when
+	// FuzzOptions.MaxAnswers is not set, for conversations that never end.
+	fuzzDefaultMaxAnswers = 300
+	// fuzzMaxRepeat bounds the repetitions of the `*`, `+` and `{n,}` operators
+	// when generating values matching a validation regexp.
+	fuzzMaxRepeat = 8
+	// fuzzGoBackOdds is the 1-in-N chance to go back instead of answering.
+	fuzzGoBackOdds = 20
+	// fuzzTranscriptTail is the number of lines of the transcript shown with a
+	// failure.
+	fuzzTranscriptTail = 15
+)
+
+// FuzzOptions configures the conversations run by Fuzz.
+type FuzzOptions struct {
+	// Runs is the number of conversations run per generator, 50 if not set.
+	Runs int
+	// Seed of the first conversation, each following one uses the next seed.
+	Seed int64
+	// MaxAnswers is the number of answers after which a conversation is
+	// reported as never ending, 300 if not set.
+	MaxAnswers int
+	// Answers are the values used for the prompts of the given action IDs,
+	// instead of random ones, written as in the answers files of RunScripted.
+	// Uploads are answered with the values as the content of the file. Ex: the
+	// ABIs to paste or upload, which cannot be made up.
+	Answers map[string][]string
+	// OnPrompt, if set, is called with each prompt asked by the conversations.
+	OnPrompt func(generator string, prompt *pbconvo.SystemOutput)
+}
+
+// FuzzFailure is a conversation run by Fuzz that didn't end well.
+type FuzzFailure struct {
+	Generator string
+	Seed      int64
+	Err       error
+	// Transcript of the conversation, up to the failure.
+	Transcript string
+}
+
+func (f *FuzzFailure) Error() string {
+	lines := strings.Split(strings.TrimRight(f.Transcript, "\n"), "\n")
+	if len(lines) > fuzzTranscriptTail {
+		lines = append([]string{"   (...)"}, lines[len(lines)-fuzzTranscriptTail:]...)
+	}
+	return fmt.Sprintf("generator %q, seed %d: %s\n%s", f.Generator, f.Seed, f.Err, strings.Join(lines, "\n"))
+}
+
+func (f *FuzzFailure) Unwrap() error {
+	return f.Err
+}
+
+// Fuzz runs conversations of every registered generator with random answers,
+// and returns the ones that didn't end well, as *FuzzFailure. It is meant to be
+// called from tests.
+func Fuzz(opts FuzzOptions) error {
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(Registry)) {
+		if err := Registry[id].Fuzz(opts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Fuzz runs conversations of the generator with a Driver, answering every prompt
+// with random but valid values: a choice of the list, a value matching the
+// validation of the text input, either answer of a confirmation, and from time
+// to time, going back. The protocol version of the client alternates with the
+// seeds, between the initial and the latest one.
+//
+// A conversation fails when it ends with an error, which happens when:
+//   - it receives a message it doesn't handle (`invalid loop message`),
+//   - a command or `Update()` panics,
+//   - it goes on forever, without asking anything or without ending,
+//   - the templates of the project fail to render,
+//   - a prompt cannot be answered with its input (ErrInvalidOutput).
+//
+// A conversation asking for something that cannot be made up, like an upload
+// without FuzzOptions.Answers for it, is stopped there, without failing.
+//
+// Commands taking a context are given a cancelled one: nothing is fetched from
+// the network, the conversation goes on as if the lookups failed.
+func (h *ConversationHandler) Fuzz(opts FuzzOptions) error {
+	runs := opts.Runs
+	if runs == 0 {
+		runs = fuzzDefaultRuns
+	}
+
+	var errs []error
+	reported := make(map[string]bool)
+	for run := 0; run < runs; run++ {
+		seed := opts.Seed + int64(run)
+		driver, err := h.fuzzRun(seed, opts)
+		if err == nil || reported[err.Error()] {
+			continue
+		}
+		reported[err.Error()] = true
+		errs = append(errs, &FuzzFailure{Generator: h.ID, Seed: seed, Err: err, Transcript: driver.Transcript()})
+	}
+	return errors.Join(errs...)
+}
+
+// fuzzRun runs the conversation of the given seed until it ends, or asks
+// something that cannot be answered.
+func (h *ConversationHandler) fuzzRun(seed int64, opts FuzzOptions) (*Driver, error) {
+	maxAnswers := opts.MaxAnswers
+	if maxAnswers == 0 {
+		maxAnswers = fuzzDefaultMaxAnswers
+	}
+
+	version := uint32(ProtocolVersionInitial)
+	if seed%2 != 0 {
+		version = ProtocolVersionLatest
+	}
+	driver, err := NewDriver(h.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	err = driver.Start("")
+	for answers := 0; err == nil && driver.Prompt() != nil; answers++ {
+		if answers == maxAnswers {
+			return driver, fmt.Errorf("conversation still going on after %d answers", maxAnswers)
+		}
+		if opts.OnPrompt != nil {
+			opts.OnPrompt(h.ID, driver.Prompt())
+		}
+		answer := fuzzAnswer(rnd, driver.Prompt(), opts.Answers, answers != 0)
+		if answer == nil {
+			return driver, nil
+		}
+		err = driver.AnswerInput(answer)
+	}
+	if err != nil && driver.generated != nil && driver.generated.Err != nil {
+		return driver, fmt.Errorf("generating the project: %w", err)
+	}
+	return driver, err
+}
+
+// fuzzAnswer returns a random answer to the prompt, nil if it cannot be answered.
+func fuzzAnswer(rnd *rand.Rand, prompt *pbconvo.SystemOutput, answers map[string][]string, canGoBack bool) *pbconvo.UserInput {
+	if canGoBack && rnd.Intn(fuzzGoBackOdds) == 0 {
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Back_{Back: &pbconvo.UserInput_Back{}}}
+	}
+
+	if values := answers[prompt.ActionId]; len(values) != 0 {
+		value := values[rnd.Intn(len(values))]
+		if upload := prompt.GetUpload(); upload != nil {
+			return &pbconvo.UserInput{Entry: &pbconvo.UserInput_File{File: &pbconvo.UserInput_Upload{
+				Filename: "fuzz.json",
+				MimeType: "application/json",
+				Content:  []byte(value),
+			}}}
+		}
+		if req, err := inputFromValue(prompt, value); err == nil {
+			return req
+		}
+	}
+
+	switch entry := prompt.Entry.(type) {
+	case *pbconvo.SystemOutput_Confirm_:
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Confirmation_{
+			Confirmation: &pbconvo.UserInput_Confirmation{Affirmative: rnd.Intn(2) == 0},
+		}}
+
+	case *pbconvo.SystemOutput_ListSelect_:
+		if selection := fuzzSelection(rnd, entry.ListSelect); selection != nil {
+			return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Selection_{Selection: selection}}
+		}
+
+	case *pbconvo.SystemOutput_TextInput_:
+		if value, ok := fuzzText(rnd, entry.TextInput); ok {
+			return &pbconvo.UserInput{Entry: &pbconvo.UserInput_TextInput_{
+				TextInput: &pbconvo.UserInput_TextInput{Value: value},
+			}}
+		}
+
+	case *pbconvo.SystemOutput_Form_:
+		response := &pbconvo.UserInput_FormResponse{}
+		for _, field := range entry.Form.Fields {
+			answer := fuzzAnswer(rnd, field.AsPrompt(), nil, false)
+			if answer == nil {
+				return nil
+			}
+			response.Fields = append(response.Fields, formResponseField(field.Name, answer))
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_FormResponse_{FormResponse: response}}
+	}
+	return nil
+}
+
+// fuzzSelection picks one of the choices of the list, half of the times the
+// default one if any (so that reviews end), or any number of them for a
+// multi-select list.
+func fuzzSelection(rnd *rand.Rand, list *pbconvo.SystemOutput_ListSelect) *pbconvo.UserInput_Selection {
+	if list.SelectMany {
+		selection := &pbconvo.UserInput_Selection{}
+		for _, value := range list.Values {
+			if rnd.Intn(2) == 0 {
+				selection.Values = append(selection.Values, value)
+				selection.Labels = append(selection.Labels, listSelectLabel(list, value))
+			}
+		}
+		return selection
+	}
+
+	value := list.DefaultValue
+	if len(list.Values) != 0 && (value == "" || rnd.Intn(2) == 0) {
+		value = list.Values[rnd.Intn(len(list.Values))]
+	}
+	if value == "" {
+		return nil
+	}
+	return &pbconvo.UserInput_Selection{Value: value, Label: listSelectLabel(list, value)}
+}
+
+// fuzzText returns either the default value of the text input, or a value
+// generated from its validation regexp, falling back to the values tried by
+// CheckPromptPairings. Inputs without validation are left empty half of the
+// times.
+func fuzzText(rnd *rand.Rand, input *pbconvo.SystemOutput_TextInput) (string, bool) {
+	re, err := regexp.Compile(input.ValidationRegexp)
+	if err != nil {
+		return "", false
+	}
+	if input.DefaultValue != "" && re.MatchString(input.DefaultValue) && rnd.Intn(3) == 0 {
+		return input.DefaultValue, true
+	}
+	if input.ValidationRegexp == "" {
+		if rnd.Intn(2) == 0 {
+			return "", true
+		}
+		return pairingTextAnswers[rnd.Intn(len(pairingTextAnswers))], true
+	}
+	if parsed, err := syntax.Parse(input.ValidationRegexp, syntax.Perl); err == nil {
+		out := &strings.Builder{}
+		fuzzRegexpValue(rnd, parsed.Simplify(), out)
+		if re.MatchString(out.String()) {
+			return out.String(), true
+		}
+	}
+	return pairingText(input)
+}
+
+// fuzzRegexpValue writes a random value matching the regexp.
+func fuzzRegexpValue(rnd *rand.Rand, re *syntax.Regexp, out *strings.Builder) {
+	repeat := func(min, max int) {
+		if max < min {
+			max = min + fuzzMaxRepeat
+		}
+		for n := min + rnd.Intn(max-min+1); n > 0; n-- {
+			fuzzRegexpValue(rnd, re.Sub[0], out)
+		}
+	}
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		out.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return
+		}
+		i := rnd.Intn(len(re.Rune)/2) * 2
+		lo, hi := re.Rune[i], re.Rune[i+1]
+		if hi-lo > 'z'-'0' {
+			hi = lo + 'z' - '0'
+		}
+		out.WriteRune(lo + rune(rnd.Intn(int(hi-lo)+1)))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		out.WriteRune(rune('a' + rnd.Intn(26)))
+	case syntax.OpCapture:
+		fuzzRegexpValue(rnd, re.Sub[0], out)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			fuzzRegexpValue(rnd, sub, out)
+		}
+	case syntax.OpAlternate:
+		fuzzRegexpValue(rnd, re.Sub[rnd.Intn(len(re.Sub))], out)
+	case syntax.OpStar:
+		repeat(0, fuzzMaxRepeat)
+	case syntax.OpPlus:
+		repeat(1, fuzzMaxRepeat)
+	case syntax.OpQuest:
+		repeat(0, 1)
+	case syntax.OpRepeat:
+		repeat(re.Min, re.Max)
+	}
+}
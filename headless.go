@@ -0,0 +1,153 @@
+package codegen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+// GeneratorFile is the `generator.json` file saved by clients: the generator ID
+// along with the conversation state to hydrate it with, and the server's signature
+// of that state when it has one.
+type GeneratorFile struct {
+	Generator string          `json:"generator"`
+	State     json.RawMessage `json:"state"`
+	Signature []byte          `json:"signature,omitempty"`
+}
+
+// IncompleteStateError is returned when a hydrated state still needs answers
+// before the project can be generated.
+type IncompleteStateError struct {
+	// Missing are the prompts the conversation would still ask, by action ID
+	// followed by their text, ex: `evm-minimal.chain_name (Please select the chain)`.
+	Missing []string
+}
+
+func (e *IncompleteStateError) Error() string {
+	return fmt.Sprintf("state is incomplete, the conversation would still ask for:\n- %s", strings.Join(e.Missing, "\n- "))
+}
+
+// GenerateFromState hydrates a new conversation of the given generator with a
+// previously saved state, exactly like a `MsgStart` with `Hydrate` would, and runs
+// it without any user interaction until the project files are generated.
+//
+// If the conversation asks for anything before reaching the review step, an
+// *IncompleteStateError listing every field still unset is returned. To find
+// the ones asked after the first, the prompts are answered with placeholder
+// values, without any lookup, until the review step or a prompt already asked.
+func GenerateFromState(ctx context.Context, generatorID string, savedState string) (map[string][]byte, error) {
+	driver, err := NewDriver(generatorID, ProtocolVersionInitial)
+	if err != nil {
+		return nil, err
+	}
+	driver.SetContext(ctx)
+
+	var missing []string
+	asked := make(map[string]bool)
+	rnd := rand.New(rand.NewSource(0))
+
+	err = driver.Start(savedState)
+	for err == nil && driver.Prompt() != nil {
+		prompt := driver.Prompt()
+		var req *pbconvo.UserInput
+		switch sel := prompt.GetListSelect(); {
+		case driver.Factory.LastInput() == reflect.TypeOf(InputReview{}):
+			if len(missing) != 0 {
+				return nil, &IncompleteStateError{Missing: missing}
+			}
+			// Nobody to review the state with, it is generated as is.
+			req, err = inputFromValue(prompt, ReviewGenerate)
+		case sel.GetSelectMany() && len(sel.DefaultValues) != 0:
+			// Without a user, multi-select lists keep their default selection
+			req, err = inputFromValue(prompt, strings.Join(sel.DefaultValues, ","))
+		default:
+			key := prompt.ActionId
+			if key == "" {
+				key = promptText(prompt)
+			}
+			if asked[key] {
+				// Either asked again for an invalid placeholder, or for another
+				// item of a list: it is listed already.
+				return nil, &IncompleteStateError{Missing: missing}
+			}
+			asked[key] = true
+			missing = append(missing, missingField(prompt))
+
+			if len(missing) == 1 {
+				// Looking up placeholder values would be pointless
+				cancelled, cancel := context.WithCancel(ctx)
+				cancel()
+				driver.SetContext(cancelled)
+			}
+			if req = placeholderAnswer(rnd, prompt); req == nil {
+				return nil, &IncompleteStateError{Missing: missing}
+			}
+		}
+		if err == nil {
+			err = driver.AnswerInput(req)
+		}
+	}
+	if len(missing) != 0 {
+		return nil, &IncompleteStateError{Missing: missing}
+	}
+	if err != nil {
+		if driver.generated != nil && driver.generated.Err != nil {
+			return nil, fmt.Errorf("generating project: %w", driver.generated.Err)
+		}
+		return nil, err
+	}
+
+	return driver.ProjectFiles(), nil
+}
+
+// missingField describes the field asked by a prompt the state has no value for.
+func missingField(prompt *pbconvo.SystemOutput) string {
+	if prompt.ActionId == "" {
+		return promptText(prompt)
+	}
+	return fmt.Sprintf("%s (%s)", prompt.ActionId, promptText(prompt))
+}
+
+// placeholderAnswer answers a prompt with its default value, or a made up valid
+// one, so that the conversation goes on to what it would ask next. Confirmations
+// are declined.
+func placeholderAnswer(rnd *rand.Rand, prompt *pbconvo.SystemOutput) *pbconvo.UserInput {
+	switch entry := prompt.Entry.(type) {
+	case *pbconvo.SystemOutput_Confirm_:
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Confirmation_{
+			Confirmation: &pbconvo.UserInput_Confirmation{Affirmative: false},
+		}}
+	case *pbconvo.SystemOutput_ListSelect_:
+		if value := entry.ListSelect.DefaultValue; value != "" && !entry.ListSelect.SelectMany {
+			req, _ := inputFromValue(prompt, value)
+			return req
+		}
+	case *pbconvo.SystemOutput_TextInput_:
+		if value := entry.TextInput.DefaultValue; value != "" {
+			req, _ := inputFromValue(prompt, value)
+			return req
+		}
+	}
+	return fuzzAnswer(rnd, prompt, nil, false)
+}
+
+// promptText returns the question asked by a SystemOutput waiting for an answer,
+// or an empty string if the output doesn't expect any.
+func promptText(msg *pbconvo.SystemOutput) string {
+	switch entry := msg.Entry.(type) {
+	case *pbconvo.SystemOutput_TextInput_:
+		return entry.TextInput.Prompt
+	case *pbconvo.SystemOutput_ListSelect_:
+		return entry.ListSelect.Instructions
+	case *pbconvo.SystemOutput_Confirm_:
+		return entry.Confirm.Prompt
+	case *pbconvo.SystemOutput_Form_:
+		return entry.Form.Prompt
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package codegen
+
+import (
+	"context"
+	"os"
+	"slices"
+)
+
+// Identity is who the user of a conversation authenticated as, when the server
+// requires authentication.
+type Identity struct {
+	// Subject identifies the user, ex: the name of their API key.
+	Subject string `json:"subject"`
+	// Tenant is the organization of the user, if any.
+	Tenant string `json:"tenant,omitempty"`
+	// Generators are the IDs of the generators the user can talk to, all of
+	// them when empty.
+	Generators []string `json:"generators,omitempty"`
+	// Env overrides the environment variables of the server for the
+	// conversations of the user, ex: to use the tenant's own explorer API
+	// keys. Never saved, it can hold secrets.
+	Env map[string]string `json:"-"`
+}
+
+// Allows reports whether the identity can talk to the generator.
+func (i *Identity) Allows(generatorID string) bool {
+	return len(i.Generators) == 0 || slices.Contains(i.Generators, generatorID)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a context carrying the identity of the user.
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity of the user carried by ctx, or nil
+// when they are not authenticated.
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+// Getenv returns the value of the environment variable key, unless the Env of
+// the identity carried by ctx overrides it.
+func Getenv(ctx context.Context, key string) string {
+	if identity := IdentityFromContext(ctx); identity != nil {
+		if value, found := identity.Env[key]; found {
+			return value
+		}
+	}
+	return os.Getenv(key)
+}
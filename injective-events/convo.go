@@ -24,6 +24,12 @@ func init() {
 		"Create an Injective Substreams module from specific events",
 		codegen.ConversationFactory(New),
 		70,
+		codegen.InputChainName{},
+		InputEventType{},
+		InputEventAttribute{},
+		InputAskAnotherEventType{},
+		InputAskInitialStartBlockType{},
+		InputDataType{},
 	)
 }
 
@@ -95,7 +101,44 @@ func (c *Convo) NextStep() (out loop.Cmd) {
 		return cmd(AskAnotherEventType{})
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
+}
+
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	var chainDisplayName string
+	if conf := p.ChainConfig(); conf != nil {
+		chainDisplayName = conf.DisplayName
+	}
+
+	fields := []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+		codegen.ChainNameField(&p.ChainName, chainDisplayName),
+		codegen.InitialBlockField(&p.InitialBlock, &p.InitialBlockSet),
+		{
+			Label: "Data type",
+			Value: p.DataType,
+			Reset: func() { p.DataType = "" },
+		},
+	}
+	for i, evt := range p.EventDescs {
+		fields = append(fields, codegen.EditableField{
+			Label: fmt.Sprintf("Event filter #%d", i+1),
+			Value: evt.GetEventQuery(),
+			Reset: func() {
+				evt.EventType = ""
+				evt.Attributes = nil
+				evt.Incomplete = true
+			},
+		})
+	}
+	fields = append(fields, codegen.EditableField{
+		Label: "Event types",
+		Value: p.GetEventsQuery(),
+		Reset: func() { p.EventsComplete = false },
+	})
+	return fields
 }
 
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
@@ -106,7 +149,9 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 			if err := json.Unmarshal([]byte(msg.Hydrate.SavedState), &c.State); err != nil {
 				return loop.Quit(fmt.Errorf(`something went wrong, here's an error message to share with our devs (%s); we've notified them already`, err))
 			}
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -283,6 +328,13 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		}
 		return nil
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
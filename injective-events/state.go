@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const EVENTS_DATA_TYPE = "events"
@@ -32,6 +35,10 @@ type Project struct {
 func (p *Project) ChainConfig() *ChainConfig { return ChainConfigByID[p.ChainName] }
 func (p *Project) KebabName() string         { return strings.ReplaceAll(p.Name, "_", "-") }
 
+func (p *Project) TraceAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{codegen.ChainAttribute.String(p.ChainName)}
+}
+
 func (e eventDesc) GetEventQuery() string {
 	attributes := make([]string, 0, len(e.Attributes))
 	for k, v := range e.Attributes {
@@ -22,6 +22,8 @@ func init() {
 		"This creating the most simple substreams on Injective Mainnet",
 		codegen.ConversationFactory(New),
 		72,
+		codegen.InputChainName{},
+		codegen.InputAskInitialStartBlockType{},
 	)
 }
 
@@ -50,7 +52,22 @@ func (c *Convo) NextStep() loop.Cmd {
 		return cmd(codegen.AskInitialStartBlockType{})
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
+}
+
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	var chainDisplayName string
+	if conf := ChainConfigByID[p.ChainName]; conf != nil {
+		chainDisplayName = conf.DisplayName
+	}
+
+	return []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+		codegen.ChainNameField(&p.ChainName, chainDisplayName),
+		codegen.InitialBlockField(&p.InitialBlock, &p.InitialBlockSet),
+	}
 }
 
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
@@ -62,7 +79,9 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				return loop.Quit(fmt.Errorf(`something went wrong, here's an error message to share with our devs (%s); we've notified them already`, err))
 			}
 
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -127,6 +146,13 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		c.State.InitialBlockSet = true
 		return c.NextStep()
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
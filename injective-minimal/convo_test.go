@@ -1,11 +1,14 @@
 package injectiveminimal
 
 import (
+	"context"
 	"testing"
 
 	codegen "github.com/streamingfast/substreams-codegen"
 	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConvoNextStep(t *testing.T) {
@@ -21,7 +24,39 @@ func TestConvoNextStep(t *testing.T) {
 	assert.Equal(t, codegen.AskChainName{}, next())
 	p.ChainName = "injective-mainnet"
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	assert.NoError(t, res.Err)
 	assert.NotEmpty(t, res.ProjectFiles)
 }
+
+func TestConvoReview(t *testing.T) {
+	convo := New()
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetClientVersion(codegen.ProtocolVersionReview)
+	convo.SetFactory(factory)
+	c := convo.(*Convo)
+	p := c.State
+	p.Name = "my-proj"
+	p.ChainName = "injective-mainnet"
+	p.InitialBlock = 1000
+	p.InitialBlockSet = true
+
+	assert.Equal(t, codegen.AskReview{}, convo.NextStep()())
+
+	fields := c.EditableFields()
+	require.Len(t, fields, 3)
+	assert.Equal(t, "1000", fields[2].Value)
+
+	review := func(value string) loop.Msg {
+		return convo.Update(codegen.InputReview{UserInput_Selection: pbconvo.UserInput_Selection{Value: value}})()
+	}
+
+	assert.Equal(t, codegen.AskInitialStartBlockType{}, review("change_2"))
+	assert.False(t, p.InitialBlockSet)
+	assert.Equal(t, "my-proj", p.Name)
+
+	p.InitialBlock = 2000
+	p.InitialBlockSet = true
+	assert.Equal(t, codegen.AskReview{}, convo.NextStep()())
+	assert.Equal(t, codegen.RunGenerate{}, review(codegen.ReviewGenerate))
+}
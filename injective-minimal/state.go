@@ -2,6 +2,9 @@ package injectiveminimal
 
 import (
 	"strings"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Project struct {
@@ -16,6 +19,10 @@ type Project struct {
 func (p *Project) ModuleName() string { return strings.ReplaceAll(p.Name, "-", "_") }
 func (p *Project) KebabName() string  { return strings.ReplaceAll(p.Name, "_", "-") }
 
+func (p *Project) TraceAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{codegen.ChainAttribute.String(p.ChainName)}
+}
+
 func (p *Project) ChainConfig() *ChainConfig          { return ChainConfigByID[p.ChainName] }
 func (p *Project) ChainNetwork() string               { return ChainConfigByID[p.ChainName].Network }
 func (p *Project) IsValidChainName(input string) bool { return ChainConfigByID[input] != nil }
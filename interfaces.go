@@ -14,9 +14,12 @@ type Converser interface {
 
 	NextStep() loop.Cmd
 	Update(loop.Msg) loop.Cmd
+	EditableFields() []EditableField
 
 	// Functions provided by the *Conversation type
 
 	SetFactory(f *MsgWrapFactory)
 	GetState() any
+	PushSnapshot()
+	PopSnapshot() bool
 }
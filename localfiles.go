@@ -0,0 +1,67 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrLocalFilesDisabled is returned when reading a local file while no root
+// directory was allowed, which is the default on the server: paths typed by users
+// would otherwise let them read any file of the server.
+var ErrLocalFilesDisabled = errors.New("reading local files is disabled on this server, upload the file instead")
+
+// SetLocalFilesRoot allows conversations to read local files (ex: `file://` ABI
+// paths) from the given directory, and its subdirectories only. An empty root
+// disables local files.
+func (f *MsgWrapFactory) SetLocalFilesRoot(root string) {
+	f.localFilesRoot = root
+}
+
+// LocalFilesEnabled reports whether ReadLocalFile can be used.
+func (c *Conversation[X]) LocalFilesEnabled() bool {
+	return c.factory.localFilesRoot != ""
+}
+
+// ReadLocalFile reads a file typed in by the user, which must resolve inside the
+// allowed root directory. Relative paths are relative to that root.
+func (c *Conversation[X]) ReadLocalFile(path string) ([]byte, error) {
+	if !c.LocalFilesEnabled() {
+		return nil, ErrLocalFilesDisabled
+	}
+
+	resolved, err := resolveInRoot(c.factory.localFilesRoot, path)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(resolved)
+}
+
+// resolveInRoot returns the real path of the file, after following symlinks, and
+// fails if it is not inside root.
+func resolveInRoot(root string, path string) (string, error) {
+	realRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("resolving local files root %q: %w", root, err)
+	}
+	realRoot, err = filepath.EvalSymlinks(realRoot)
+	if err != nil {
+		return "", fmt.Errorf("resolving local files root %q: %w", root, err)
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(realRoot, path)
+	}
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", fmt.Errorf("resolving %q: %w", path, err)
+	}
+
+	rel, err := filepath.Rel(realRoot, realPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q is outside of the allowed directory", path)
+	}
+	return realPath, nil
+}
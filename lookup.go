@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrLookupLimit is the cause of the cancellation of the lookups made past the
+// limit set by WithLookupLimit.
+var ErrLookupLimit = errors.New("lookup limit reached")
+
+type lookupLimitKey struct{}
+
+type lookupLimit struct {
+	remaining atomic.Int64
+	reached   func()
+	once      sync.Once
+}
+
+// WithLookupLimit returns a context allowing up to max lookups through
+// StartLookup. The context of the lookups past it is cancelled with
+// ErrLookupLimit as cause, and reached is called on the first of them.
+func WithLookupLimit(ctx context.Context, max int, reached func()) context.Context {
+	limit := &lookupLimit{reached: reached}
+	limit.remaining.Store(int64(max))
+	return context.WithValue(ctx, lookupLimitKey{}, limit)
+}
+
+// StartLookup starts the span of a call to a block explorer or RPC endpoint of
+// the chain, to be made with the returned context. lookup names what is looked
+// up, ex: `abi`. The returned function ends the span and records the metrics of
+// the call, which failed if err is not nil.
+func StartLookup(ctx context.Context, chain, lookup string) (context.Context, func(err error)) {
+	if limit, ok := ctx.Value(lookupLimitKey{}).(*lookupLimit); ok && limit.remaining.Add(-1) < 0 {
+		limit.once.Do(limit.reached)
+		ctx, cancel := context.WithCancelCause(ctx)
+		cancel(ErrLookupLimit)
+		return ctx, func(error) {}
+	}
+
+	begin := time.Now()
+	ctx, span := otelTracer().Start(ctx, "lookup "+lookup, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		ChainAttribute.String(chain),
+		LookupAttribute.String(lookup),
+	))
+	return ctx, func(err error) {
+		LookupDuration.WithLabelValues(chain, lookup).Observe(time.Since(begin).Seconds())
+		if err != nil {
+			LookupErrors.Inc(chain, lookup)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
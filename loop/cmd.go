@@ -1,6 +1,7 @@
 package loop
 
 import (
+	"context"
 	"time"
 )
 
@@ -40,6 +41,33 @@ func Batch(cmds ...Cmd) Cmd {
 	}
 }
 
+// WithContextMsg is a command that needs a context, run by the loop.
+type WithContextMsg struct {
+	Run func(ctx context.Context) Msg
+	// Timeout, if not zero, bounds the duration of the command instead of the
+	// loop's command timeout.
+	Timeout time.Duration
+}
+
+// WithContext makes a command out of a function that is given a context,
+// cancelled when the loop ends, when the command is abandoned through
+// DropInFlight, or when it runs longer than the loop's command timeout. Use it
+// for anything doing network calls.
+func WithContext(cmd func(ctx context.Context) Msg) Cmd {
+	return func() Msg {
+		return WithContextMsg{Run: cmd}
+	}
+}
+
+// WithContextTimeout is like WithContext, with the given timeout instead of the
+// loop's command timeout. Use it for commands which need a limit of their own,
+// like lookups that are slower than others, or that have a fallback.
+func WithContextTimeout(timeout time.Duration, cmd func(ctx context.Context) Msg) Cmd {
+	return func() Msg {
+		return WithContextMsg{Run: cmd, Timeout: timeout}
+	}
+}
+
 type SeqMsg []Cmd
 
 func Seq(cmds ...Cmd) Cmd {
@@ -56,6 +84,11 @@ type QuitMsg struct {
 	err error
 }
 
+// Err returns the error the loop quits with, nil when it ends normally.
+func (m QuitMsg) Err() error {
+	return m.err
+}
+
 func Quit(err error) Cmd {
 	return func() Msg {
 		return QuitMsg{err}
@@ -1,7 +1,29 @@
 package loop
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer returns the tracer starting a span for every command run by the loops.
+// It is taken from the global tracer provider on each call, for the spans to
+// follow when the provider is replaced.
+func tracer() trace.Tracer {
+	return otel.Tracer("github.com/streamingfast/substreams-codegen/loop")
+}
+
+const (
+	originAttribute = attribute.Key("loop.origin")
+	resultAttribute = attribute.Key("loop.result")
 )
 
 // loop is the micro framework for the Scheduler's event loop,
@@ -11,20 +33,77 @@ type EventLoop struct {
 	ctx        context.Context
 	msgs       chan Msg
 	updateFunc func(msg Msg) Cmd
+
+	// epoch is bumped by DropInFlight, results of commands started in a
+	// previous epoch are ignored.
+	epoch *atomic.Uint64
+	// epochCtx is given to the WithContext commands started in the current
+	// epoch, and cancelled by DropInFlight or when the loop ends.
+	epochCtx    context.Context
+	cancelEpoch context.CancelFunc
+
+	// commandTimeout, if not zero, bounds the duration of WithContext commands
+	// without a timeout of their own.
+	commandTimeout time.Duration
+	// commandObserver, if set, is given the result and duration of every
+	// WithContext command.
+	commandObserver func(result Msg, elapsed time.Duration)
+	// spanAttributes are added to the span of every command.
+	spanAttributes []attribute.KeyValue
+}
+
+// epochCmd is a command along with the epoch it was scheduled in, and its
+// origin: the type of the message whose update returned it.
+type epochCmd struct {
+	epoch  uint64
+	origin string
+	cmd    Cmd
+}
+
+// epochMsg is the result of a command along with the epoch it was started in,
+// and the origin of the command.
+type epochMsg struct {
+	epoch  uint64
+	origin string
+	msg    Msg
 }
 
 func NewEventLoop(updateFunc func(msg Msg) Cmd) EventLoop {
 	return EventLoop{
 		msgs:       make(chan Msg, 1000),
 		updateFunc: updateFunc,
+		epoch:      &atomic.Uint64{},
 	}
 }
 
+// SetCommandTimeout bounds the duration of the WithContext commands without a
+// timeout of their own: their context is cancelled once it has elapsed. Zero
+// means no timeout.
+func (l *EventLoop) SetCommandTimeout(timeout time.Duration) {
+	l.commandTimeout = timeout
+}
+
+// SetCommandObserver sets a function called with the result and duration of
+// every WithContext command, from the goroutine that ran it.
+func (l *EventLoop) SetCommandObserver(observer func(result Msg, elapsed time.Duration)) {
+	l.commandObserver = observer
+}
+
+// SetSpanAttributes sets attributes added to the span of every command, like
+// what the loop is running.
+func (l *EventLoop) SetSpanAttributes(attributes ...attribute.KeyValue) {
+	l.spanAttributes = attributes
+}
+
 func (l *EventLoop) Run(ctx context.Context, initCmd Cmd) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	// commands still running are abandoned along with the loop
+	defer cancel()
 	l.ctx = ctx
-	cmds := make(chan Cmd, 1000)
+	l.epochCtx, l.cancelEpoch = context.WithCancel(ctx)
+	cmds := make(chan epochCmd, 1000)
 	if initCmd != nil {
-		cmds <- initCmd
+		cmds <- epochCmd{l.epoch.Load(), "init", initCmd}
 	}
 	// main execution loop
 	done := make(chan struct{})
@@ -36,25 +115,58 @@ loop:
 			err = l.ctx.Err()
 			break loop
 		case msg := <-l.msgs:
+			resultOf := ""
+			if result, ok := msg.(epochMsg); ok {
+				if result.epoch != l.epoch.Load() {
+					// Result of a command abandoned through DropInFlight
+					continue
+				}
+				msg, resultOf = result.msg, result.origin
+			}
+
 			if quit, ok := msg.(QuitMsg); ok {
 				err = quit.err
 				break loop
 			}
 
-			cmd := l.update(msg, cmds)
+			origin := originOf(msg, resultOf)
+			cmd, panicErr := l.safeUpdate(msg, origin, cmds)
+			if _, ok := msg.(PanicMsg); panicErr != nil && !ok {
+				// An update panicking is told about like a command panicking
+				msg = PanicMsg{Err: panicErr}
+				cmd, panicErr = l.safeUpdate(msg, origin, cmds)
+			}
+			if panicked, ok := msg.(PanicMsg); ok {
+				if panicErr != nil {
+					// telling about the panic panicked too
+					err = panicked.Err
+					break loop
+				}
+				cmd = Seq(cmd, Quit(panicked.Err))
+			}
 			if cmd == nil {
 				continue
 			}
-			go func() {
-				msg := cmd()
-				l.Send(msg)
-			}()
+			l.start(epochCmd{l.epoch.Load(), origin, cmd})
 		}
 	}
 	close(done)
 	return
 }
 
+// originOf returns the origin of the commands returned by the update of msg:
+// the type of msg or, for the messages only scheduling commands, the origin of
+// the command they are the result of.
+func originOf(msg Msg, resultOf string) string {
+	switch msg.(type) {
+	case BatchMsg, SeqMsg, WithContextMsg:
+		if resultOf != "" {
+			return resultOf
+		}
+	}
+	return fmt.Sprintf("%T", msg)
+}
+
 func (l *EventLoop) Send(msg Msg) {
 	select {
 	case <-l.ctx.Done():
@@ -62,20 +174,104 @@ func (l *EventLoop) Send(msg Msg) {
 	}
 }
 
-func (l *EventLoop) update(msg Msg, cmds chan Cmd) (out Cmd) {
+// DropInFlight makes the loop ignore the results of all the commands started so
+// far, for example when the user goes back and the step they were for is abandoned.
+// It must be called from the update function.
+func (l *EventLoop) DropInFlight() {
+	if l.epoch != nil {
+		l.epoch.Add(1)
+	}
+	if l.cancelEpoch != nil {
+		l.cancelEpoch()
+		l.epochCtx, l.cancelEpoch = context.WithCancel(l.ctx)
+	}
+}
+
+func (l *EventLoop) start(cmd epochCmd) {
+	go func() {
+		begin := time.Now()
+		msg := safe(cmd.cmd) // this can be long.
+		l.traceCommand(cmd.origin, begin, msg)
+		l.Send(epochMsg{cmd.epoch, cmd.origin, msg})
+	}()
+}
+
+// traceCommand records the span of a command that ran from begin and returned
+// msg. The commands only scheduling others have none, those they schedule do.
+func (l *EventLoop) traceCommand(origin string, begin time.Time, msg Msg) {
+	switch msg.(type) {
+	case BatchMsg, SeqMsg, WithContextMsg:
+		return
+	}
+	_, span := l.startSpan(l.ctx, origin, begin)
+	endSpan(span, msg)
+}
+
+func (l *EventLoop) startSpan(ctx context.Context, origin string, begin time.Time) (context.Context, trace.Span) {
+	return tracer().Start(ctx, "Cmd "+origin, trace.WithTimestamp(begin), trace.WithAttributes(
+		append([]attribute.KeyValue{originAttribute.String(origin)}, l.spanAttributes...)...,
+	))
+}
+
+func endSpan(span trace.Span, result Msg) {
+	span.SetAttributes(resultAttribute.String(fmt.Sprintf("%T", result)))
+	if panicked, ok := result.(PanicMsg); ok {
+		span.RecordError(panicked.Err)
+		span.SetStatus(codes.Error, panicked.Err.Error())
+	}
+	span.End()
+}
+
+// safeUpdate runs the update function, turning a panic into a PanicError.
+func (l *EventLoop) safeUpdate(msg Msg, origin string, cmds chan epochCmd) (cmd Cmd, err *PanicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return l.update(msg, origin, cmds), nil
+}
+
+func (l *EventLoop) update(msg Msg, origin string, cmds chan epochCmd) (out Cmd) {
 	switch msg := msg.(type) {
 	case BatchMsg:
 		for _, cmd := range msg {
-			cmds <- cmd
+			cmds <- epochCmd{l.epoch.Load(), origin, cmd}
 		}
 		return nil
 
+	case WithContextMsg:
+		// Run here rather than returned, for its span to be in the context
+		// given to it.
+		run, epoch := l.withContextRunner(origin), l.epoch.Load()
+		go func() {
+			l.Send(epochMsg{epoch, origin, run(msg)})
+		}()
+		return nil
+
 	case SeqMsg:
+		run, epoch := l.withContextRunner(origin), l.epoch.Load()
 		go func() {
 			// Execute commands one at a time, in order.
 			for _, cmd := range msg {
-				if cmd != nil {
-					l.Send(cmd())
+				if l.epoch.Load() != epoch {
+					return
+				}
+				if cmd == nil {
+					continue
+				}
+				begin := time.Now()
+				msg := safe(cmd)
+				if withContext, ok := msg.(WithContextMsg); ok {
+					// Run inline, for the rest of the sequence to wait for it
+					msg = run(withContext)
+				} else {
+					l.traceCommand(origin, begin, msg)
+				}
+				l.Send(epochMsg{epoch, origin, msg})
+				if _, ok := msg.(PanicMsg); ok {
+					// the rest of the sequence relies on what failed
+					return
 				}
 			}
 		}()
@@ -85,21 +281,40 @@ func (l *EventLoop) update(msg Msg, cmds chan Cmd) (out Cmd) {
 	return l.updateFunc(msg)
 }
 
-func (l *EventLoop) handleCommands(done chan struct{}, cmds chan Cmd) {
+// withContextRunner returns a function running WithContext commands with the
+// context, timeout and observer of the current epoch, from any goroutine.
+func (l *EventLoop) withContextRunner(origin string) func(cmd WithContextMsg) Msg {
+	epochCtx, timeout, observer := l.epochCtx, l.commandTimeout, l.commandObserver
+	return func(cmd WithContextMsg) Msg {
+		ctx := epochCtx
+		if timeout := cmp.Or(cmd.Timeout, timeout); timeout != 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		begin := time.Now()
+		ctx, span := l.startSpan(ctx, origin, begin)
+		result := safe(func() Msg { return cmd.Run(ctx) })
+		endSpan(span, result)
+		if observer != nil {
+			observer(result, time.Since(begin))
+		}
+		return result
+	}
+}
+
+func (l *EventLoop) handleCommands(done chan struct{}, cmds chan epochCmd) {
 	for {
 		select {
 		case <-done:
 			return
 
 		case cmd := <-cmds:
-			if cmd == nil {
+			if cmd.cmd == nil {
 				continue
 			}
 
-			go func() {
-				msg := cmd() // this can be long.
-				l.Send(msg)
-			}()
+			l.start(cmd)
 		}
 	}
 }
@@ -0,0 +1,198 @@
+package loop
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// told is what the update function returns when told about a panic.
+type told struct{}
+
+func TestCommandPanic(t *testing.T) {
+	var seen []Msg
+	l := NewEventLoop(func(msg Msg) Cmd {
+		seen = append(seen, msg)
+		if _, ok := msg.(PanicMsg); ok {
+			return func() Msg { return told{} }
+		}
+		return nil
+	})
+
+	err := l.Run(context.Background(), func() Msg { panic("boom") })
+
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	require.Len(t, seen, 2)
+	assert.Equal(t, PanicMsg{Err: panicErr}, seen[0])
+	assert.Equal(t, told{}, seen[1])
+}
+
+func TestUpdatePanic(t *testing.T) {
+	var seen []Msg
+	l := NewEventLoop(func(msg Msg) Cmd {
+		seen = append(seen, msg)
+		switch msg.(type) {
+		case string:
+			panic("boom")
+		case PanicMsg:
+			return func() Msg { return told{} }
+		}
+		return nil
+	})
+
+	err := l.Run(context.Background(), func() Msg { return "start" })
+
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	require.Len(t, seen, 3)
+	assert.Equal(t, "start", seen[0])
+	assert.Equal(t, PanicMsg{Err: panicErr}, seen[1])
+	assert.Equal(t, told{}, seen[2])
+}
+
+func TestUpdatePanicWhileToldAboutPanic(t *testing.T) {
+	l := NewEventLoop(func(msg Msg) Cmd {
+		if _, ok := msg.(PanicMsg); ok {
+			panic("again")
+		}
+		return nil
+	})
+
+	err := l.Run(context.Background(), func() Msg { panic("boom") })
+
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+}
+
+func TestSeqWaitsForWithContext(t *testing.T) {
+	var seen []Msg
+	l := NewEventLoop(func(msg Msg) Cmd {
+		seen = append(seen, msg)
+		if msg == "second" {
+			return Quit(nil)
+		}
+		return nil
+	})
+
+	err := l.Run(context.Background(), Seq(
+		WithContext(func(ctx context.Context) Msg {
+			time.Sleep(20 * time.Millisecond)
+			return "first"
+		}),
+		func() Msg { return "second" },
+	))
+
+	require.NoError(t, err)
+	assert.Equal(t, []Msg{"first", "second"}, seen)
+}
+
+func TestDropInFlight(t *testing.T) {
+	started, cancelled := make(chan struct{}), make(chan struct{})
+	var cancelErr error
+	var seen []Msg
+	var l EventLoop
+	l = NewEventLoop(func(msg Msg) Cmd {
+		seen = append(seen, msg)
+		switch msg {
+		case "back":
+			l.DropInFlight()
+			return func() Msg {
+				<-cancelled
+				// leaves time for the abandoned result to reach the loop
+				time.Sleep(20 * time.Millisecond)
+				return "done"
+			}
+		case "done":
+			return Quit(nil)
+		}
+		return nil
+	})
+	go func() {
+		<-started
+		l.Send("back")
+	}()
+
+	var observed []Msg
+	var mu sync.Mutex
+	l.SetCommandObserver(func(result Msg, elapsed time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		observed = append(observed, result)
+	})
+	err := l.Run(context.Background(), WithContext(func(ctx context.Context) Msg {
+		close(started)
+		<-ctx.Done()
+		cancelErr = ctx.Err()
+		close(cancelled)
+		return "abandoned"
+	}))
+
+	require.NoError(t, err)
+	assert.Equal(t, context.Canceled, cancelErr)
+	assert.Equal(t, []Msg{"back", "done"}, seen)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []Msg{"abandoned"}, observed)
+}
+
+func TestCommandTimeout(t *testing.T) {
+	var seen []Msg
+	var observed []time.Duration
+	l := NewEventLoop(func(msg Msg) Cmd {
+		seen = append(seen, msg)
+		if len(seen) == 2 {
+			return Quit(nil)
+		}
+		return nil
+	})
+	l.SetCommandTimeout(10 * time.Millisecond)
+	l.SetCommandObserver(func(result Msg, elapsed time.Duration) {
+		observed = append(observed, elapsed)
+	})
+
+	waitDone := func(ctx context.Context) Msg {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	err := l.Run(context.Background(), Seq(WithContext(waitDone), WithContext(waitDone)))
+
+	require.NoError(t, err)
+	assert.Equal(t, []Msg{context.DeadlineExceeded, context.DeadlineExceeded}, seen)
+	require.Len(t, observed, 2)
+	for _, elapsed := range observed {
+		assert.GreaterOrEqual(t, elapsed, 10*time.Millisecond)
+	}
+}
+
+func TestWithContextTimeout(t *testing.T) {
+	run := func(loopTimeout time.Duration, cmd Cmd) Msg {
+		var result Msg
+		l := NewEventLoop(func(msg Msg) Cmd {
+			result = msg
+			return Quit(nil)
+		})
+		l.SetCommandTimeout(loopTimeout)
+		require.NoError(t, l.Run(context.Background(), cmd))
+		return result
+	}
+
+	// a longer limit than the loop's one
+	assert.Nil(t, run(10*time.Millisecond, WithContextTimeout(time.Hour, func(ctx context.Context) Msg {
+		time.Sleep(30 * time.Millisecond)
+		return ctx.Err()
+	})))
+
+	// a limit when the loop has none
+	assert.Equal(t, context.DeadlineExceeded, run(0, WithContextTimeout(10*time.Millisecond, func(ctx context.Context) Msg {
+		<-ctx.Done()
+		return ctx.Err()
+	})))
+}
@@ -0,0 +1,34 @@
+package loop
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error of a command, or of the update function, that panicked.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// PanicMsg is delivered to the update function in place of the result of a
+// command that panicked, or right after the update of a message panicked. The
+// loop then quits with its error, once the command returned by the update
+// function (to tell the user, for example) has run.
+type PanicMsg struct {
+	Err *PanicError
+}
+
+// safe runs the command, turning a panic into a PanicMsg.
+func safe(cmd Cmd) (msg Msg) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = PanicMsg{Err: &PanicError{Value: r, Stack: debug.Stack()}}
+		}
+	}()
+	return cmd()
+}
@@ -0,0 +1,55 @@
+package codegen
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/streamingfast/dmetrics"
+)
+
+// Metrics of the conversations, served on the `--metrics-listen-addr` of the
+// server once RegisterMetrics is called.
+var MetricSet = dmetrics.NewSet(dmetrics.PrefixNameWith("codegen"))
+
+var ConversationsStarted = MetricSet.NewCounterVec("conversations_started", []string{"generator"}, "Number of conversations started")
+var ConversationsEnded = MetricSet.NewCounterVec("conversations_ended", []string{"generator", "outcome"}, "Number of conversations ended, by outcome: completed, error or abandoned")
+var ActiveConversations = MetricSet.NewGaugeVec("active_conversations", []string{"generator"}, "Number of conversations in progress")
+var LookupErrors = MetricSet.NewCounterVec("lookup_errors", []string{"chain", "lookup"}, "Number of failed calls to block explorers and RPC endpoints, by chain and lookup (ex: abi, initial_block)")
+var LimitedConversations = MetricSet.NewCounterVec("limited_conversations", []string{"limit"}, "Number of conversations refused or closed for reaching a limit: concurrent, rate or lookups")
+
+// The histograms don't go through the MetricSet, which only has the default
+// buckets, made for durations of a few seconds at most.
+var (
+	PromptDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "codegen_prompt_duration_seconds",
+		Help:    "Time taken by users to answer a prompt, by action ID",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12), // 1s to ~34min
+	}, []string{"generator", "action_id"})
+
+	LookupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "codegen_lookup_duration_seconds",
+		Help:    "Duration of the calls to block explorers and RPC endpoints, by chain and lookup (ex: abi, initial_block)",
+		Buckets: prometheus.ExponentialBuckets(0.05, 2, 12), // 50ms to ~100s
+	}, []string{"chain", "lookup"})
+
+	GenerationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "codegen_generation_duration_seconds",
+		Help:    "Time taken to generate the project files",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12), // 10ms to ~20s
+	}, []string{"generator"})
+
+	GenerationSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "codegen_generation_size_bytes",
+		Help:    "Total size of the generated project files",
+		Buckets: prometheus.ExponentialBuckets(1024, 2, 14), // 1KiB to 8MiB
+	}, []string{"generator"})
+)
+
+var registerOnce sync.Once
+
+func RegisterMetrics() {
+	registerOnce.Do(func() {
+		MetricSet.Register()
+		dmetrics.PrometheusRegister(PromptDuration, LookupDuration, GenerationDuration, GenerationSize)
+	})
+}
@@ -3,29 +3,80 @@ package codegen
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/streamingfast/substreams-codegen/loop"
 	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type MsgStart struct {
 	pbconvo.UserInput_Start
+
+	// Unsigned is set when the hydrated state did not carry a valid signature
+	// from this server, and the server policy is to accept it anyway. Generators
+	// should not trust anything in it that was fetched or validated server-side.
+	Unsigned bool
+}
+
+// NewMsgStart wraps the start input received from a client. The protobuf message
+// is copied field by field, as it must not be copied by value.
+func NewMsgStart(start *pbconvo.UserInput_Start, unsigned bool) MsgStart {
+	return MsgStart{
+		UserInput_Start: pbconvo.UserInput_Start{
+			GeneratorId: start.GeneratorId,
+			Hydrate:     start.Hydrate,
+			Version:     start.Version,
+		},
+		Unsigned: unsigned,
+	}
+}
+
+// Reconnecting reports whether the client is reconnecting to a conversation that
+// dropped, as opposed to starting or resetting one. Generators then skip any
+// greeting and go straight back to the pending prompt.
+func (m *MsgStart) Reconnecting() bool {
+	return IsReconnect(m.Hydrate)
+}
+
+// Humanize renders the start of the conversation, as in the session logs.
+func (m *MsgStart) Humanize(seconds int) string {
+	return fmt.Sprintf("%4d┃ [Start, hydrate: %t] %s", seconds, m.Hydrate != nil, m.GeneratorId)
+}
+
+// IsReconnect reports whether the hydrate continues a dropped conversation from
+// its last message, instead of resetting it.
+func IsReconnect(hydrate *pbconvo.UserInput_Hydrate) bool {
+	return hydrate != nil && hydrate.LastMsgId != 0 && !hydrate.ResetConversation
 }
 
 type IncomingMessage struct {
 	Msg any
+	// Input is the UserInput the message was decoded from.
+	Input *pbconvo.UserInput
 }
 
 func (m *IncomingMessage) Humanize(seconds int) string {
+	if m.Msg == nil {
+		return ("---")
+	}
 	if h, ok := m.Msg.(pbconvo.Humanizable); ok {
 		return h.Humanize(seconds)
 	}
-	if m.Msg == nil {
-		return ("---")
+	// inputs embedding a message humanized through a pointer receiver
+	ptr := reflect.New(reflect.TypeOf(m.Msg))
+	ptr.Elem().Set(reflect.ValueOf(m.Msg))
+	if h, ok := ptr.Interface().(pbconvo.Humanizable); ok {
+		return h.Humanize(seconds)
 	}
 
 	return fmt.Sprintf("%d | %T %v", seconds, m.Msg, m.Msg)
@@ -34,7 +85,19 @@ func (m *IncomingMessage) Humanize(seconds int) string {
 type MsgWrapFactory struct {
 	sendFunc   SendFunc
 	inputTypes map[string]reflect.Type
+	signer     *StateSigner
+
+	// lastMu protects the last prompt, set by the update function and read when
+	// decoding the answer, which the server does in another goroutine.
+	lastMu     sync.Mutex
 	lastType   reflect.Type
+	lastPrompt *pbconvo.SystemOutput
+
+	generatorID    string
+	clientVersion  uint32
+	localFilesRoot string
+	commandTimeout time.Duration
+	identity       *Identity
 
 	loop.EventLoop
 }
@@ -49,10 +112,40 @@ func NewMsgWrapFactory(sendFunc SendFunc) *MsgWrapFactory {
 
 func (f *MsgWrapFactory) SetupLoop(updateFunc func(msg loop.Msg) loop.Cmd) {
 	f.EventLoop = loop.NewEventLoop(updateFunc)
+	f.EventLoop.SetCommandTimeout(f.commandTimeout)
+	f.EventLoop.SetSpanAttributes(GeneratorAttribute.String(f.generatorID))
+}
+
+// SetCommandTimeout bounds the duration of the WithContext commands without a
+// timeout of their own, like the generation of the project. It must be called
+// before SetupLoop.
+func (f *MsgWrapFactory) SetCommandTimeout(timeout time.Duration) {
+	f.commandTimeout = timeout
+}
+
+// SetStateSigner makes every message carrying a state also carry its signature.
+func (f *MsgWrapFactory) SetStateSigner(signer *StateSigner) {
+	f.signer = signer
+}
+
+// SetGeneratorID sets the generator prefixing the action IDs of the prompts,
+// and carried by the spans of the commands. It must be called before SetupLoop.
+func (f *MsgWrapFactory) SetGeneratorID(generatorID string) {
+	f.generatorID = generatorID
+}
+
+// SetIdentity records who the user authenticated as, nil when they are not.
+func (f *MsgWrapFactory) SetIdentity(identity *Identity) {
+	f.identity = identity
+}
+
+// SetClientVersion records the protocol version advertised by the client in `Start`.
+func (f *MsgWrapFactory) SetClientVersion(version uint32) {
+	f.clientVersion = version
 }
 
 func (f *MsgWrapFactory) NewMsg(state any) *MsgWrap {
-	w := &MsgWrap{}
+	w := &MsgWrap{clientVersion: f.clientVersion}
 	w.Msg = &pbconvo.SystemOutput{}
 	if state != nil {
 		cnt, err := json.Marshal(state)
@@ -60,6 +153,9 @@ func (f *MsgWrapFactory) NewMsg(state any) *MsgWrap {
 			panic(err)
 		}
 		w.Msg.State = string(cnt)
+		if f.signer != nil {
+			w.Msg.StateSignature = f.signer.Sign(w.Msg.State)
+		}
 	}
 	return w
 }
@@ -67,22 +163,147 @@ func (f *MsgWrapFactory) NewMsg(state any) *MsgWrap {
 func (f *MsgWrapFactory) NewInput(inputMsg any, state any) *MsgWrap {
 	msg := f.NewMsg(state)
 	reflectType := reflect.TypeOf(inputMsg)
+	f.lastMu.Lock()
 	f.lastType = reflectType
-	el := reflect.New(reflectType).Interface()
-	_, ok := el.(protoreflect.ProtoMessage)
-	if !ok {
-		panic("only use NewInput with messages that embed a return value of type pbconvo.UserInput_*")
+	f.lastPrompt = msg.Msg
+	f.lastMu.Unlock()
+	if reflectType == nil || reflectType.Kind() != reflect.Struct {
+		msg.fail(fmt.Errorf("input %T must be a struct embedding one of the pbconvo.UserInput_* messages", inputMsg))
+		return msg
+	}
+	msg.inputType = reflectType
+	msg.Msg.ActionId = ActionID(f.generatorID, reflectType)
+	if _, ok := reflect.New(reflectType).Interface().(protoreflect.ProtoMessage); !ok {
+		msg.fail(fmt.Errorf("input %s must embed one of the pbconvo.UserInput_* messages", reflectType))
 	}
 	return msg
 }
 
 func (f *MsgWrapFactory) LastInput() reflect.Type {
+	f.lastMu.Lock()
+	defer f.lastMu.Unlock()
 	return f.lastType
 }
 
+// DecodeInput converts a UserInput answering the last prompt into the typed
+// input message that was registered through NewInput. An input that doesn't
+// satisfy the constraints of the prompt is decoded as a MsgInvalidInput.
+func (f *MsgWrapFactory) DecodeInput(req *pbconvo.UserInput) (IncomingMessage, error) {
+	if _, ok := req.Entry.(*pbconvo.UserInput_Back_); ok {
+		return IncomingMessage{Msg: MsgGoBack{}, Input: req}, nil
+	}
+
+	f.lastMu.Lock()
+	reflectType, prompt := f.lastType, f.lastPrompt
+	f.lastMu.Unlock()
+
+	reason, err := checkInput(prompt, req)
+	if err != nil {
+		return IncomingMessage{}, err
+	}
+	if reason != "" {
+		return IncomingMessage{Msg: MsgInvalidInput{Reason: reason, Prompt: prompt}, Input: req}, nil
+	}
+
+	if reflectType == nil {
+		// TODO: make this a "BadRequest" or InvalidRequest error, shown to the user
+		return IncomingMessage{}, fmt.Errorf("message type %q was not registered or does not exist", req.FromActionId)
+	}
+	newMsg := reflect.New(reflectType)
+	newProtoMsg, ok := newMsg.Interface().(protoreflect.ProtoMessage)
+	if !ok {
+		return IncomingMessage{}, fmt.Errorf("input %s does not embed any pbconvo.UserInput_* message", reflectType)
+	}
+
+	var input proto.Message
+	switch entry := req.Entry.(type) {
+	case *pbconvo.UserInput_Confirmation_:
+		input = entry.Confirmation
+	case *pbconvo.UserInput_Selection_:
+		input = entry.Selection
+	case *pbconvo.UserInput_TextInput_:
+		input = entry.TextInput
+	case *pbconvo.UserInput_DownloadedFiles_:
+		input = entry.DownloadedFiles
+	case *pbconvo.UserInput_File:
+		input = entry.File
+	case *pbconvo.UserInput_FormResponse_:
+		input = entry.FormResponse
+	default:
+		return IncomingMessage{}, fmt.Errorf("unknown entry type %T", entry)
+	}
+
+	cnt, err := proto.Marshal(input)
+	if err != nil {
+		return IncomingMessage{}, fmt.Errorf("marshal type %T: %w", input, err)
+	}
+	err = proto.Unmarshal(cnt, newProtoMsg)
+	if err != nil {
+		return IncomingMessage{}, fmt.Errorf("unmarshal into type %T from %T: %w", newProtoMsg, input, err)
+	}
+	return IncomingMessage{Msg: newMsg.Elem().Interface(), Input: req}, nil
+}
+
 type MsgWrap struct {
 	Msg *pbconvo.SystemOutput
 	Err error
+
+	// inputType is the input given to Action(), which the answer to the prompt
+	// is decoded into.
+	inputType     reflect.Type
+	formField     bool
+	clientVersion uint32
+}
+
+// ErrInvalidOutput is reported by Cmd() when the MsgWrap was misused: a prompt
+// that its Action() input cannot answer, or a modifier called on an entry it
+// doesn't apply to, like Labels() on a TextInput.
+var ErrInvalidOutput = errors.New("invalid output")
+
+// fail keeps the first misuse of the MsgWrap, reported by Cmd().
+func (w *MsgWrap) fail(err error) {
+	if w.Err == nil {
+		w.Err = err
+	}
+}
+
+func (w *MsgWrap) unsupported(method string) {
+	w.fail(fmt.Errorf("%s cannot be used on %s", method, entryKind(w.Msg.Entry)))
+}
+
+// expectInput checks that the input given to Action() embeds the `UserInput`
+// entry answering the prompt being built, ex: `pbconvo.UserInput_Confirmation`
+// for a Confirm.
+func (w *MsgWrap) expectInput(prompt string, entry reflect.Type) {
+	if w.formField {
+		// answered as part of the form
+		return
+	}
+	if w.inputType == nil {
+		w.fail(fmt.Errorf("%s prompt has no input to be answered with, it must be built with Action()", prompt))
+		return
+	}
+	if !embedsInput(w.inputType, entry) {
+		w.fail(fmt.Errorf("%s prompt cannot be answered with %s, it must embed pbconvo.%s", prompt, w.inputType, entry.Name()))
+	}
+}
+
+func embedsInput(inputType reflect.Type, entry reflect.Type) bool {
+	for i := 0; i < inputType.NumField(); i++ {
+		if field := inputType.Field(i); field.Anonymous && field.Type == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// entryKind names the entry of a SystemOutput in error messages, ex: `a ListSelect`.
+func entryKind(entry any) string {
+	if entry == nil {
+		return "an empty output"
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(fmt.Sprintf("%T", entry), "*pbconvo.SystemOutput_"), "_")
+	return "a " + name
 }
 
 func (w *MsgWrap) Messagef(markdown string, args ...interface{}) *MsgWrap {
@@ -105,7 +326,7 @@ func (w *MsgWrap) Message(markdown string) *MsgWrap {
 }
 
 func (w *MsgWrap) Confirm(prompt string, acceptLabel, declineLabel string) *MsgWrap {
-	// TODO: to a type assertion on the `lastType`, to make sure it matches what we're asking here..
+	w.expectInput("Confirm", reflect.TypeFor[pbconvo.UserInput_Confirmation]())
 	w.Msg.Entry = &pbconvo.SystemOutput_Confirm_{
 		Confirm: &pbconvo.SystemOutput_Confirm{
 			Prompt:             prompt,
@@ -121,7 +342,7 @@ func (w *MsgWrap) DefaultAccept() *MsgWrap {
 	case *pbconvo.SystemOutput_Confirm_:
 		entry.Confirm.DefaultButton = pbconvo.SystemOutput_Confirm_CONFIRM
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("DefaultAccept")
 	}
 	return w
 }
@@ -131,13 +352,13 @@ func (w *MsgWrap) DefaultDecline() *MsgWrap {
 	case *pbconvo.SystemOutput_Confirm_:
 		entry.Confirm.DefaultButton = pbconvo.SystemOutput_Confirm_DECLINE
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("DefaultDecline")
 	}
 	return w
 }
 
 func (w *MsgWrap) DownloadFiles() *MsgWrap {
-	// TODO: to a type assertion on the `lastType`, to make sure it matches what we're asking here..
+	w.expectInput("DownloadFiles", reflect.TypeFor[pbconvo.UserInput_DownloadedFiles]())
 	w.Msg.Entry = &pbconvo.SystemOutput_DownloadFiles_{
 		DownloadFiles: &pbconvo.SystemOutput_DownloadFiles{},
 	}
@@ -156,7 +377,7 @@ func (w *MsgWrap) AddFile(filename string, cnt []byte, fileType string, descript
 			Description: description,
 		})
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("AddFile")
 	}
 	return w
 }
@@ -191,7 +412,7 @@ func (w *MsgWrap) Loadingf(loading bool, label string, args ...interface{}) *Msg
 }
 
 func (w *MsgWrap) TextInput(prompt string, submitButtonLabel string) *MsgWrap {
-	// TODO: to a type assertion on the `lastType`, to make sure it matches what we're asking here..
+	w.expectInput("TextInput", reflect.TypeFor[pbconvo.UserInput_TextInput]())
 	w.Msg.Entry = &pbconvo.SystemOutput_TextInput_{
 		TextInput: &pbconvo.SystemOutput_TextInput{
 			Prompt:            prompt,
@@ -208,7 +429,7 @@ func (w *MsgWrap) DefaultValue(value string) *MsgWrap {
 	case *pbconvo.SystemOutput_ListSelect_:
 		entry.ListSelect.DefaultValue = value
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("DefaultValue")
 	}
 	return w
 }
@@ -218,14 +439,98 @@ func (w *MsgWrap) Description(description string) *MsgWrap {
 		entry.TextInput.Description = description
 	case *pbconvo.SystemOutput_Confirm_:
 		entry.Confirm.Description = description
+	case *pbconvo.SystemOutput_Upload_:
+		entry.Upload.Description = description
+	case *pbconvo.SystemOutput_Form_:
+		entry.Form.Description = description
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("Description")
 	}
 	return w
 }
 
+func (w *MsgWrap) Upload(prompt string, submitButtonLabel string) *MsgWrap {
+	w.expectInput("Upload", reflect.TypeFor[pbconvo.UserInput_Upload]())
+	w.Msg.Entry = &pbconvo.SystemOutput_Upload_{
+		Upload: &pbconvo.SystemOutput_Upload{
+			Prompt:            prompt,
+			SubmitButtonLabel: submitButtonLabel,
+		},
+	}
+	return w
+}
+
+func (w *MsgWrap) AcceptedMimeTypes(mimeTypes ...string) *MsgWrap {
+	switch entry := w.Msg.Entry.(type) {
+	case *pbconvo.SystemOutput_Upload_:
+		entry.Upload.AcceptedMimeTypes = mimeTypes
+	default:
+		w.unsupported("AcceptedMimeTypes")
+	}
+	return w
+}
+
+func (w *MsgWrap) MaxSize(bytes uint64) *MsgWrap {
+	switch entry := w.Msg.Entry.(type) {
+	case *pbconvo.SystemOutput_Upload_:
+		entry.Upload.MaxSize = bytes
+	default:
+		w.unsupported("MaxSize")
+	}
+	return w
+}
+
+// Form asks all the fields added with Field() at once, answered with a
+// `UserInput.FormResponse`. Clients that don't support ProtocolVersionForm
+// should be asked the same fields one at a time instead.
+func (w *MsgWrap) Form(prompt string, submitButtonLabel string) *MsgWrap {
+	w.expectInput("Form", reflect.TypeFor[pbconvo.UserInput_FormResponse]())
+	w.Msg.Entry = &pbconvo.SystemOutput_Form_{
+		Form: &pbconvo.SystemOutput_Form{
+			Prompt:            prompt,
+			SubmitButtonLabel: submitButtonLabel,
+		},
+	}
+	return w
+}
+
+// NewFormField starts a form field, built like a standalone TextInput, ListSelect
+// or Confirm prompt and added to a form with Field().
+func NewFormField() *MsgWrap {
+	return &MsgWrap{Msg: &pbconvo.SystemOutput{}, formField: true}
+}
+
+// Field adds a field to the form, under the name identifying its answer in the
+// `UserInput.FormResponse`.
+func (w *MsgWrap) Field(name string, field *MsgWrap) *MsgWrap {
+	entry, ok := w.Msg.Entry.(*pbconvo.SystemOutput_Form_)
+	if !ok {
+		w.unsupported("Field")
+		return w
+	}
+	if field.Err != nil {
+		w.fail(fmt.Errorf("form field %q: %w", name, field.Err))
+		return w
+	}
+
+	formField := &pbconvo.SystemOutput_Form_Field{Name: name}
+	switch fieldEntry := field.Msg.Entry.(type) {
+	case *pbconvo.SystemOutput_TextInput_:
+		formField.Entry = &pbconvo.SystemOutput_Form_Field_TextInput{TextInput: fieldEntry.TextInput}
+	case *pbconvo.SystemOutput_ListSelect_:
+		formField.Entry = &pbconvo.SystemOutput_Form_Field_ListSelect{ListSelect: fieldEntry.ListSelect}
+	case *pbconvo.SystemOutput_Confirm_:
+		formField.Entry = &pbconvo.SystemOutput_Form_Field_Confirm{Confirm: fieldEntry.Confirm}
+	default:
+		w.fail(fmt.Errorf("form field %q cannot be %s", name, entryKind(field.Msg.Entry)))
+		return w
+	}
+	entry.Form.Fields = append(entry.Form.Fields, formField)
+	return w
+}
+
 func (w *MsgWrap) ListSelect(instructions string) *MsgWrap {
-	// TODO: to a type assertion on the `lastType`, to make sure it matches what we're asking here..
+	w.expectInput("ListSelect", reflect.TypeFor[pbconvo.UserInput_Selection]())
 	w.Msg.Entry = &pbconvo.SystemOutput_ListSelect_{
 		ListSelect: &pbconvo.SystemOutput_ListSelect{
 			Instructions: instructions,
@@ -240,7 +545,7 @@ func (w *MsgWrap) Labels(labels ...string) *MsgWrap {
 		entry.ListSelect.Labels = labels
 		entry.ListSelect.Values = labels
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("Labels")
 	}
 	return w
 }
@@ -250,7 +555,7 @@ func (w *MsgWrap) SelectButton(label string) *MsgWrap {
 	case *pbconvo.SystemOutput_ListSelect_:
 		entry.ListSelect.SelectButtonLabel = label
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("SelectButton")
 	}
 	return w
 }
@@ -260,7 +565,29 @@ func (w *MsgWrap) Values(values ...string) *MsgWrap {
 	case *pbconvo.SystemOutput_ListSelect_:
 		entry.ListSelect.Values = values
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("Values")
+	}
+	return w
+}
+
+// SelectMany lets the user pick any number of entries of the list, answered with
+// all the selected values in `Selection.values`.
+func (w *MsgWrap) SelectMany() *MsgWrap {
+	switch entry := w.Msg.Entry.(type) {
+	case *pbconvo.SystemOutput_ListSelect_:
+		entry.ListSelect.SelectMany = true
+	default:
+		w.unsupported("SelectMany")
+	}
+	return w
+}
+
+func (w *MsgWrap) DefaultValues(values ...string) *MsgWrap {
+	switch entry := w.Msg.Entry.(type) {
+	case *pbconvo.SystemOutput_ListSelect_:
+		entry.ListSelect.DefaultValues = values
+	default:
+		w.unsupported("DefaultValues")
 	}
 	return w
 }
@@ -270,7 +597,7 @@ func (w *MsgWrap) Placeholder(message string) *MsgWrap {
 	case *pbconvo.SystemOutput_TextInput_:
 		entry.TextInput.Placeholder = message
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("Placeholder")
 	}
 	return w
 }
@@ -280,7 +607,7 @@ func (w *MsgWrap) Multiline(val int) *MsgWrap {
 	case *pbconvo.SystemOutput_TextInput_:
 		entry.TextInput.MultiLine = int32(val)
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("Multiline")
 	}
 	return w
 }
@@ -291,11 +618,80 @@ func (w *MsgWrap) Validation(regexp string, errorMessage string) *MsgWrap {
 		entry.TextInput.ValidationRegexp = regexp
 		entry.TextInput.ValidationErrorMessage = errorMessage
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("Validation")
+	}
+	return w
+}
+
+// Table displays the rows added with Row(), under the given column names.
+func (w *MsgWrap) Table(title string, columns ...string) *MsgWrap {
+	w.Msg.Entry = &pbconvo.SystemOutput_Table_{
+		Table: &pbconvo.SystemOutput_Table{
+			Title:   title,
+			Columns: columns,
+		},
+	}
+	return w
+}
+
+func (w *MsgWrap) Row(cells ...string) *MsgWrap {
+	switch entry := w.Msg.Entry.(type) {
+	case *pbconvo.SystemOutput_Table_:
+		entry.Table.Rows = append(entry.Table.Rows, &pbconvo.SystemOutput_Table_Row{Cells: cells})
+	default:
+		w.unsupported("Row")
 	}
 	return w
 }
 
+// Code displays a snippet of source code in the given language, ex: "protobuf".
+func (w *MsgWrap) Code(title string, language string, content string) *MsgWrap {
+	w.Msg.Entry = &pbconvo.SystemOutput_Code_{
+		Code: &pbconvo.SystemOutput_Code{
+			Title:    title,
+			Language: language,
+			Content:  content,
+		},
+	}
+	return w
+}
+
+// FileTree displays the paths of the files, along with their sizes.
+func (w *MsgWrap) FileTree(title string, files map[string][]byte) *MsgWrap {
+	tree := &pbconvo.SystemOutput_FileTree{Title: title}
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		tree.Files = append(tree.Files, &pbconvo.SystemOutput_FileTree_File{
+			Path: path,
+			Size: uint64(len(files[path])),
+		})
+	}
+	w.Msg.Entry = &pbconvo.SystemOutput_FileTree_{FileTree: tree}
+	return w
+}
+
+// downgrade replaces the entries the client doesn't support by their markdown
+// rendering.
+func (w *MsgWrap) downgrade() {
+	if w.clientVersion >= ProtocolVersionRichOutput {
+		return
+	}
+
+	var markdown string
+	switch entry := w.Msg.Entry.(type) {
+	case *pbconvo.SystemOutput_Table_:
+		markdown = entry.Table.Markdown()
+	case *pbconvo.SystemOutput_Code_:
+		markdown = entry.Code.Markdown()
+	case *pbconvo.SystemOutput_FileTree_:
+		markdown = entry.FileTree.Markdown()
+	default:
+		return
+	}
+	w.Msg.Entry = &pbconvo.SystemOutput_Message_{
+		Message: &pbconvo.SystemOutput_Message{Markdown: markdown},
+	}
+}
+
 func tplMe(templateText string, data interface{}) string {
 	tpl, err := template.New("tpl").Parse(templateText)
 	if err != nil {
@@ -321,14 +717,17 @@ func (w *MsgWrap) Style(style string) *MsgWrap {
 	case *pbconvo.SystemOutput_Message_:
 		entry.Message.Style = style
 	default:
-		panic("unsupported message type for this method")
+		w.unsupported("Style")
 	}
 	return w
 }
 
 // This will wait for an answer
 func (w *MsgWrap) Cmd() loop.Cmd {
-	// Make sure this is called only on those that EXPECT a return value
+	if w.Err != nil {
+		return loop.Quit(fmt.Errorf("%w: %w", ErrInvalidOutput, w.Err))
+	}
+	w.downgrade()
 	return func() loop.Msg {
 		return w.Msg
 	}
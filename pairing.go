@@ -0,0 +1,230 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+const (
+	// pairingMaxPrompts bounds the distinct prompts walked per generator and
+	// protocol version.
+	pairingMaxPrompts = 400
+	// pairingMaxPerAction bounds the times the answers to prompts of the same
+	// action are walked, in favor of the actions not reached yet.
+	pairingMaxPerAction = 6
+	// pairingMaxChoices is the length above which only some choices of a list
+	// are walked.
+	pairingMaxChoices = 16
+)
+
+// pairingTextAnswers are tried, in order, on text inputs without a default value
+// or placeholder matching their validation.
+var pairingTextAnswers = []string{"my_project", "1", "0x" + strings.Repeat("0", 40), "0x" + strings.Repeat("0", 64)}
+
+// CheckPromptPairings goes through the conversations of every registered
+// generator, and returns the prompts that cannot be answered with the input
+// given to their Action(), along with any other ErrInvalidOutput reported by
+// MsgWrap. It is meant to be called from tests.
+//
+// The conversations are also walked from the saved states of the `seeds`, to
+// reach the prompts coming after the ones that cannot be answered automatically.
+func CheckPromptPairings(seeds ...*GeneratorFile) error {
+	savedStates := make(map[string][]string)
+	for _, seed := range seeds {
+		savedStates[seed.Generator] = append(savedStates[seed.Generator], string(seed.State))
+	}
+
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(Registry)) {
+		if err := Registry[id].CheckPromptPairings(savedStates[id]...); err != nil {
+			errs = append(errs, fmt.Errorf("generator %q: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckPromptPairings checks that every input of the generator embeds a `UserInput`
+// entry, and walks its conversation, for both the initial and the latest protocol
+// versions, to find the prompts reported as ErrInvalidOutput. The conversation
+// is walked from its start, and from each of the saved states.
+//
+// Every choice of the confirmations and short lists is followed, text inputs are
+// answered with their default value or a value matching their validation. Uploads
+// are never answered, so what comes after them is only walked from saved states.
+// Commands taking a context are given a cancelled one: nothing is fetched from
+// the network, the conversation goes on as if the lookups failed.
+func (h *ConversationHandler) CheckPromptPairings(savedStates ...string) error {
+	var errs []error
+	reported := make(map[string]bool)
+	report := func(err error) {
+		if !reported[err.Error()] {
+			reported[err.Error()] = true
+			errs = append(errs, err)
+		}
+	}
+
+	for _, input := range append(append([]any{}, commonInputs...), h.Inputs...) {
+		if inputEntryName(reflect.TypeOf(input)) == "" {
+			report(fmt.Errorf("input %T doesn't embed any pbconvo.UserInput_* message", input))
+		}
+	}
+
+	for _, version := range []uint32{ProtocolVersionInitial, ProtocolVersionLatest} {
+		seen := make(map[string]bool)
+		expanded := make(map[string]int)
+		paths := []pairingPath{{}}
+		for _, savedState := range savedStates {
+			paths = append(paths, pairingPath{savedState: savedState})
+		}
+		for len(paths) != 0 && len(seen) < pairingMaxPrompts {
+			path := paths[0]
+			paths = paths[1:]
+
+			prompt, err := h.replay(version, path.savedState, path.answers)
+			if err != nil {
+				report(fmt.Errorf("protocol version %d: %w", version, err))
+				continue
+			}
+			if prompt == nil {
+				continue
+			}
+			key := prompt.ActionId + "\x00" + prompt.State
+			if seen[key] || expanded[prompt.ActionId] == pairingMaxPerAction {
+				continue
+			}
+			seen[key] = true
+			expanded[prompt.ActionId]++
+
+			for _, answer := range pairingAnswers(prompt) {
+				paths = append(paths, pairingPath{savedState: path.savedState, answers: append(slices.Clone(path.answers), answer)})
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type pairingPath struct {
+	savedState string
+	answers    []*pbconvo.UserInput
+}
+
+// replay runs a new conversation with a Driver, hydrated with the saved state if
+// any, answering its prompts in order. It returns the prompt asked once all the
+// answers are used, or nil when the conversation ends before that.
+//
+// Only the errors showing a broken prompt are returned: the conversation ending
+// with any other error (like a lookup failing) is not a pairing issue.
+func (h *ConversationHandler) replay(version uint32, savedState string, answers []*pbconvo.UserInput) (*pbconvo.SystemOutput, error) {
+	driver, err := NewDriver(h.ID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	err = driver.Start(savedState)
+	for _, answer := range answers {
+		if err != nil || driver.Prompt() == nil {
+			break
+		}
+		err = driver.AnswerInput(answer)
+	}
+	if err == nil {
+		return driver.Prompt(), nil
+	}
+
+	var panicErr *loop.PanicError
+	if done, _ := driver.Done(); !done || errors.Is(err, ErrInvalidOutput) || errors.As(err, &panicErr) || errors.Is(err, errDriverLoop) {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// pairingAnswers returns the answers to walk for the prompt.
+func pairingAnswers(prompt *pbconvo.SystemOutput) []*pbconvo.UserInput {
+	switch entry := prompt.Entry.(type) {
+	case *pbconvo.SystemOutput_Confirm_:
+		return []*pbconvo.UserInput{
+			{Entry: &pbconvo.UserInput_Confirmation_{Confirmation: &pbconvo.UserInput_Confirmation{Affirmative: true}}},
+			{Entry: &pbconvo.UserInput_Confirmation_{Confirmation: &pbconvo.UserInput_Confirmation{Affirmative: false}}},
+		}
+
+	case *pbconvo.SystemOutput_ListSelect_:
+		var out []*pbconvo.UserInput
+		for _, selection := range pairingSelections(entry.ListSelect) {
+			out = append(out, &pbconvo.UserInput{Entry: &pbconvo.UserInput_Selection_{Selection: selection}})
+		}
+		return out
+
+	case *pbconvo.SystemOutput_TextInput_:
+		if value, ok := pairingText(entry.TextInput); ok {
+			return []*pbconvo.UserInput{{Entry: &pbconvo.UserInput_TextInput_{TextInput: &pbconvo.UserInput_TextInput{Value: value}}}}
+		}
+
+	case *pbconvo.SystemOutput_Form_:
+		response := &pbconvo.UserInput_FormResponse{}
+		for _, field := range entry.Form.Fields {
+			answers := pairingAnswers(field.AsPrompt())
+			if len(answers) == 0 {
+				return nil
+			}
+			formField := &pbconvo.UserInput_FormResponse_Field{Name: field.Name}
+			switch answer := answers[0].Entry.(type) {
+			case *pbconvo.UserInput_TextInput_:
+				formField.Entry = &pbconvo.UserInput_FormResponse_Field_TextInput{TextInput: answer.TextInput}
+			case *pbconvo.UserInput_Selection_:
+				formField.Entry = &pbconvo.UserInput_FormResponse_Field_Selection{Selection: answer.Selection}
+			case *pbconvo.UserInput_Confirmation_:
+				formField.Entry = &pbconvo.UserInput_FormResponse_Field_Confirmation{Confirmation: answer.Confirmation}
+			}
+			response.Fields = append(response.Fields, formField)
+		}
+		return []*pbconvo.UserInput{{Entry: &pbconvo.UserInput_FormResponse_{FormResponse: response}}}
+	}
+	return nil
+}
+
+// pairingSelections returns every choice of a short list, only the default, first
+// and last ones of a long list (like the chains), or the default and empty
+// selections of a multi-select list.
+func pairingSelections(list *pbconvo.SystemOutput_ListSelect) []*pbconvo.UserInput_Selection {
+	if list.SelectMany {
+		return []*pbconvo.UserInput_Selection{{Values: list.DefaultValues}, {}}
+	}
+
+	candidates := append([]string{list.DefaultValue}, list.Values...)
+	if len(list.Values) > pairingMaxChoices {
+		candidates = []string{list.DefaultValue, list.Values[0], list.Values[len(list.Values)-1]}
+	}
+	var values []string
+	for _, value := range candidates {
+		if value != "" && !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	var out []*pbconvo.UserInput_Selection
+	for _, value := range values {
+		out = append(out, &pbconvo.UserInput_Selection{Value: value})
+	}
+	return out
+}
+
+func pairingText(input *pbconvo.SystemOutput_TextInput) (string, bool) {
+	re, err := regexp.Compile(input.ValidationRegexp)
+	if err != nil {
+		return "", false
+	}
+	for _, value := range append([]string{input.DefaultValue, input.Placeholder}, pairingTextAnswers...) {
+		if value != "" && re.MatchString(value) {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -27,6 +27,9 @@ func (msg *SystemOutput) Humanize(seconds int) string {
 		for i, v := range sel.Values {
 			vals = append(vals, fmt.Sprintf("%s- %s (%s)", nl, sel.Labels[i], v))
 		}
+		if sel.SelectMany {
+			return fmt.Sprintf("%s%s (select many)%s", time, wrapnl(sel.Instructions), strings.Join(vals, ""))
+		}
 		return fmt.Sprintf("%s%s%s", time, wrapnl(sel.Instructions), strings.Join(vals, ""))
 	case msg.GetTextInput() != nil:
 		inp := msg.GetTextInput()
@@ -40,6 +43,23 @@ func (msg *SystemOutput) Humanize(seconds int) string {
 			filenames = append(filenames, f.Filename)
 		}
 		return fmt.Sprintf("%s[Downloading files]%s%s", time, nl+"- ", strings.Join(filenames, nl+"- "))
+	case msg.GetUpload() != nil:
+		upload := msg.GetUpload()
+		return fmt.Sprintf("%s%s%s%s%s[ upload: %s ]", time, wrapnl(upload.Prompt), nl, wrapnl(upload.Description), nl, strings.Join(upload.AcceptedMimeTypes, ", "))
+	case msg.GetForm() != nil:
+		form := msg.GetForm()
+		fields := make([]string, 0, len(form.Fields))
+		for _, field := range form.Fields {
+			fields = append(fields, fmt.Sprintf("%s- %s [%s]", nl, wrapnl(field.Label()), field.Name))
+		}
+		return fmt.Sprintf("%s%s%s%s%s", time, wrapnl(form.Prompt), nl, wrapnl(form.Description), strings.Join(fields, ""))
+	case msg.GetTable() != nil:
+		return fmt.Sprintf("%s%s", time, wrapnl(msg.GetTable().Text()))
+	case msg.GetCode() != nil:
+		code := msg.GetCode()
+		return fmt.Sprintf("%s[ %s ] %s%s%s", time, code.Language, code.Title, nl, wrapnl(strings.TrimSuffix(code.Content, "\n")))
+	case msg.GetFileTree() != nil:
+		return fmt.Sprintf("%s%s", time, wrapnl(msg.GetFileTree().Text()))
 	case msg.GetLoading() != nil:
 		loading := msg.GetLoading()
 		return time + "Loading ..." + loading.Label
@@ -47,11 +67,117 @@ func (msg *SystemOutput) Humanize(seconds int) string {
 	return fmt.Sprintf("%s, %v", msg.ActionId, msg.Entry)
 }
 
+// IsPrompt reports whether the output waits for an answer from the user.
+func (msg *SystemOutput) IsPrompt() bool {
+	return msg.GetListSelect() != nil || msg.GetTextInput() != nil || msg.GetConfirm() != nil || msg.GetUpload() != nil || msg.GetForm() != nil
+}
+
+// Label returns the question asked by the form field.
+func (f *SystemOutput_Form_Field) Label() string {
+	switch entry := f.Entry.(type) {
+	case *SystemOutput_Form_Field_TextInput:
+		return entry.TextInput.Prompt
+	case *SystemOutput_Form_Field_ListSelect:
+		return entry.ListSelect.Instructions
+	case *SystemOutput_Form_Field_Confirm:
+		return entry.Confirm.Prompt
+	}
+	return f.Name
+}
+
+// AsPrompt returns the field as the standalone prompt of the same type.
+func (f *SystemOutput_Form_Field) AsPrompt() *SystemOutput {
+	out := &SystemOutput{}
+	switch entry := f.Entry.(type) {
+	case *SystemOutput_Form_Field_TextInput:
+		out.Entry = &SystemOutput_TextInput_{TextInput: entry.TextInput}
+	case *SystemOutput_Form_Field_ListSelect:
+		out.Entry = &SystemOutput_ListSelect_{ListSelect: entry.ListSelect}
+	case *SystemOutput_Form_Field_Confirm:
+		out.Entry = &SystemOutput_Confirm_{Confirm: entry.Confirm}
+	}
+	return out
+}
+
+// Field returns the answer to the form field of the given name, or nil if the
+// response doesn't have it.
+func (r *UserInput_FormResponse) Field(name string) *UserInput_FormResponse_Field {
+	for _, field := range r.Fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// TextValue returns the value entered in the text field of the given name.
+func (r *UserInput_FormResponse) TextValue(name string) string {
+	return r.Field(name).GetTextInput().GetValue()
+}
+
+// SelectionValue returns the value selected in the list field of the given name.
+func (r *UserInput_FormResponse) SelectionValue(name string) string {
+	return r.Field(name).GetSelection().GetValue()
+}
+
+// Confirmed reports whether the confirm field of the given name was accepted.
+func (r *UserInput_FormResponse) Confirmed(name string) bool {
+	return r.Field(name).GetConfirmation().GetAffirmative()
+}
+
+func (r *UserInput_FormResponse) Humanize(seconds int) string {
+	time := fmt.Sprintf("%4d ", seconds)
+	values := make([]string, 0, len(r.Fields))
+	for _, field := range r.Fields {
+		values = append(values, fmt.Sprintf("%s=%s", field.Name, field.humanValue()))
+	}
+	return fmt.Sprintf("%s[Form] %s", time, strings.Join(values, ", "))
+}
+
+func (f *UserInput_FormResponse_Field) humanValue() string {
+	switch entry := f.Entry.(type) {
+	case *UserInput_FormResponse_Field_TextInput:
+		return entry.TextInput.Value
+	case *UserInput_FormResponse_Field_Selection:
+		return strings.Join(entry.Selection.SelectedValues(), ",")
+	case *UserInput_FormResponse_Field_Confirmation:
+		return fmt.Sprintf("%t", entry.Confirmation.Affirmative)
+	}
+	return ""
+}
+
+// AsInput returns the answer as the input answering the standalone prompt of
+// the same type.
+func (f *UserInput_FormResponse_Field) AsInput() *UserInput {
+	out := &UserInput{}
+	switch entry := f.Entry.(type) {
+	case *UserInput_FormResponse_Field_TextInput:
+		out.Entry = &UserInput_TextInput_{TextInput: entry.TextInput}
+	case *UserInput_FormResponse_Field_Selection:
+		out.Entry = &UserInput_Selection_{Selection: entry.Selection}
+	case *UserInput_FormResponse_Field_Confirmation:
+		out.Entry = &UserInput_Confirmation_{Confirmation: entry.Confirmation}
+	}
+	return out
+}
+
 func (i UserInput_Selection) Humanize(seconds int) string {
 	time := fmt.Sprintf("%4d ", seconds)
+	if len(i.Values) != 0 {
+		return fmt.Sprintf("%s[Selected] %s", time, strings.Join(i.Values, ", "))
+	}
 	return fmt.Sprintf("%s[Selected] %s (%s)", time, i.Label, i.Value)
 }
 
+// SelectedValues returns the values of a `select_many` answer, or the single
+// selected value otherwise.
+func (i *UserInput_Selection) SelectedValues() []string {
+	if len(i.Values) != 0 || i.Value == "" {
+		return i.Values
+	}
+	return []string{i.Value}
+}
+
 func (i UserInput_TextInput) Humanize(seconds int) string {
 	time := fmt.Sprintf("%4d ", seconds)
 	return fmt.Sprintf("%s%s", time, i.Value)
@@ -71,6 +197,11 @@ func (i UserInput_File) Humanize(seconds int) string {
 	return fmt.Sprintf("< [Uploaded file]: %s", i.File.Filename)
 }
 
+func (i *UserInput_Upload) Humanize(seconds int) string {
+	time := fmt.Sprintf("%4d ", seconds)
+	return fmt.Sprintf("%s[Uploaded file] %s (%s, %d bytes)", time, i.Filename, i.MimeType, len(i.Content))
+}
+
 // not used
 //func (i UserInput_Start) Humanize() string {
 //	return fmt.Sprintf("< [Start, hydrate: %t] %s", i.Hydrate != nil, i.GeneratorId)
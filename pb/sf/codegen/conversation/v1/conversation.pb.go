@@ -112,7 +112,7 @@ func (x SystemOutput_Confirm_Button) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SystemOutput_Confirm_Button.Descriptor instead.
 func (SystemOutput_Confirm_Button) EnumDescriptor() ([]byte, []int) {
-	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 7, 0}
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 12, 0}
 }
 
 type Empty struct {
@@ -162,13 +162,14 @@ type UserInput struct {
 	FromMsgId    uint32 `protobuf:"varint,2,opt,name=from_msg_id,json=fromMsgId,proto3" json:"from_msg_id,omitempty"`
 	FromActionId string `protobuf:"bytes,3,opt,name=from_action_id,json=fromActionId,proto3" json:"from_action_id,omitempty"`
 	// Types that are assignable to Entry:
-	//
 	//	*UserInput_Start_
 	//	*UserInput_TextInput_
 	//	*UserInput_Selection_
 	//	*UserInput_Confirmation_
 	//	*UserInput_File
 	//	*UserInput_DownloadedFiles_
+	//	*UserInput_Back_
+	//	*UserInput_FormResponse_
 	Entry isUserInput_Entry `protobuf_oneof:"entry"`
 }
 
@@ -274,6 +275,20 @@ func (x *UserInput) GetDownloadedFiles() *UserInput_DownloadedFiles {
 	return nil
 }
 
+func (x *UserInput) GetBack() *UserInput_Back {
+	if x, ok := x.GetEntry().(*UserInput_Back_); ok {
+		return x.Back
+	}
+	return nil
+}
+
+func (x *UserInput) GetFormResponse() *UserInput_FormResponse {
+	if x, ok := x.GetEntry().(*UserInput_FormResponse_); ok {
+		return x.FormResponse
+	}
+	return nil
+}
+
 type isUserInput_Entry interface {
 	isUserInput_Entry()
 }
@@ -299,9 +314,18 @@ type UserInput_File struct {
 }
 
 type UserInput_DownloadedFiles_ struct {
+	// Deprecated: we don't use this.
 	DownloadedFiles *UserInput_DownloadedFiles `protobuf:"bytes,20,opt,name=downloaded_files,json=downloadedFiles,proto3,oneof"`
 }
 
+type UserInput_Back_ struct {
+	Back *UserInput_Back `protobuf:"bytes,21,opt,name=back,proto3,oneof"`
+}
+
+type UserInput_FormResponse_ struct {
+	FormResponse *UserInput_FormResponse `protobuf:"bytes,22,opt,name=form_response,json=formResponse,proto3,oneof"`
+}
+
 func (*UserInput_Start_) isUserInput_Entry() {}
 
 func (*UserInput_TextInput_) isUserInput_Entry() {}
@@ -314,6 +338,10 @@ func (*UserInput_File) isUserInput_Entry() {}
 
 func (*UserInput_DownloadedFiles_) isUserInput_Entry() {}
 
+func (*UserInput_Back_) isUserInput_Entry() {}
+
+func (*UserInput_FormResponse_) isUserInput_Entry() {}
+
 type SystemOutput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -325,7 +353,6 @@ type SystemOutput struct {
 	State          string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`                                         // to be saved each step, if connection drops, Init back with this state
 	StateSignature []byte `protobuf:"bytes,5,opt,name=state_signature,json=stateSignature,proto3" json:"state_signature,omitempty"` // Optional, or future ?
 	// Types that are assignable to Entry:
-	//
 	//	*SystemOutput_Message_
 	//	*SystemOutput_ImageWithText_
 	//	*SystemOutput_ListSelect_
@@ -333,6 +360,11 @@ type SystemOutput struct {
 	//	*SystemOutput_Confirm_
 	//	*SystemOutput_Loading_
 	//	*SystemOutput_DownloadFiles_
+	//	*SystemOutput_Upload_
+	//	*SystemOutput_Form_
+	//	*SystemOutput_Table_
+	//	*SystemOutput_Code_
+	//	*SystemOutput_FileTree_
 	Entry isSystemOutput_Entry `protobuf_oneof:"entry"`
 }
 
@@ -459,6 +491,41 @@ func (x *SystemOutput) GetDownloadFiles() *SystemOutput_DownloadFiles {
 	return nil
 }
 
+func (x *SystemOutput) GetUpload() *SystemOutput_Upload {
+	if x, ok := x.GetEntry().(*SystemOutput_Upload_); ok {
+		return x.Upload
+	}
+	return nil
+}
+
+func (x *SystemOutput) GetForm() *SystemOutput_Form {
+	if x, ok := x.GetEntry().(*SystemOutput_Form_); ok {
+		return x.Form
+	}
+	return nil
+}
+
+func (x *SystemOutput) GetTable() *SystemOutput_Table {
+	if x, ok := x.GetEntry().(*SystemOutput_Table_); ok {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *SystemOutput) GetCode() *SystemOutput_Code {
+	if x, ok := x.GetEntry().(*SystemOutput_Code_); ok {
+		return x.Code
+	}
+	return nil
+}
+
+func (x *SystemOutput) GetFileTree() *SystemOutput_FileTree {
+	if x, ok := x.GetEntry().(*SystemOutput_FileTree_); ok {
+		return x.FileTree
+	}
+	return nil
+}
+
 type isSystemOutput_Entry interface {
 	isSystemOutput_Entry()
 }
@@ -491,6 +558,26 @@ type SystemOutput_DownloadFiles_ struct {
 	DownloadFiles *SystemOutput_DownloadFiles `protobuf:"bytes,20,opt,name=download_files,json=downloadFiles,proto3,oneof"`
 }
 
+type SystemOutput_Upload_ struct {
+	Upload *SystemOutput_Upload `protobuf:"bytes,22,opt,name=upload,proto3,oneof"`
+}
+
+type SystemOutput_Form_ struct {
+	Form *SystemOutput_Form `protobuf:"bytes,23,opt,name=form,proto3,oneof"`
+}
+
+type SystemOutput_Table_ struct {
+	Table *SystemOutput_Table `protobuf:"bytes,24,opt,name=table,proto3,oneof"`
+}
+
+type SystemOutput_Code_ struct {
+	Code *SystemOutput_Code `protobuf:"bytes,25,opt,name=code,proto3,oneof"`
+}
+
+type SystemOutput_FileTree_ struct {
+	FileTree *SystemOutput_FileTree `protobuf:"bytes,26,opt,name=file_tree,json=fileTree,proto3,oneof"`
+}
+
 func (*SystemOutput_Message_) isSystemOutput_Entry() {}
 
 func (*SystemOutput_ImageWithText_) isSystemOutput_Entry() {}
@@ -505,6 +592,16 @@ func (*SystemOutput_Loading_) isSystemOutput_Entry() {}
 
 func (*SystemOutput_DownloadFiles_) isSystemOutput_Entry() {}
 
+func (*SystemOutput_Upload_) isSystemOutput_Entry() {}
+
+func (*SystemOutput_Form_) isSystemOutput_Entry() {}
+
+func (*SystemOutput_Table_) isSystemOutput_Entry() {}
+
+func (*SystemOutput_Code_) isSystemOutput_Entry() {}
+
+func (*SystemOutput_FileTree_) isSystemOutput_Entry() {}
+
 type DiscoveryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -855,6 +952,9 @@ type UserInput_Selection struct {
 
 	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
 	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// When answering a `select_many` list, all the selected entries. `label` and `value` are then unused.
+	Labels []string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+	Values []string `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`
 }
 
 func (x *UserInput_Selection) Reset() {
@@ -903,6 +1003,20 @@ func (x *UserInput_Selection) GetValue() string {
 	return ""
 }
 
+func (x *UserInput_Selection) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UserInput_Selection) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type UserInput_Confirmation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -950,6 +1064,94 @@ func (x *UserInput_Confirmation) GetAffirmative() bool {
 	return false
 }
 
+// Back undoes the last answer, and asks the previous question again.
+type UserInput_Back struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UserInput_Back) Reset() {
+	*x = UserInput_Back{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserInput_Back) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserInput_Back) ProtoMessage() {}
+
+func (x *UserInput_Back) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserInput_Back.ProtoReflect.Descriptor instead.
+func (*UserInput_Back) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{1, 6}
+}
+
+// FormResponse answers a `SystemOutput.Form`, with one entry per field of the form.
+type UserInput_FormResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields []*UserInput_FormResponse_Field `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *UserInput_FormResponse) Reset() {
+	*x = UserInput_FormResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserInput_FormResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserInput_FormResponse) ProtoMessage() {}
+
+func (x *UserInput_FormResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserInput_FormResponse.ProtoReflect.Descriptor instead.
+func (*UserInput_FormResponse) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{1, 7}
+}
+
+func (x *UserInput_FormResponse) GetFields() []*UserInput_FormResponse_Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Deprecated: this isn't used
 type UserInput_DownloadedFiles struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -959,7 +1161,7 @@ type UserInput_DownloadedFiles struct {
 func (x *UserInput_DownloadedFiles) Reset() {
 	*x = UserInput_DownloadedFiles{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[11]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -972,7 +1174,7 @@ func (x *UserInput_DownloadedFiles) String() string {
 func (*UserInput_DownloadedFiles) ProtoMessage() {}
 
 func (x *UserInput_DownloadedFiles) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[11]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -985,9 +1187,111 @@ func (x *UserInput_DownloadedFiles) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserInput_DownloadedFiles.ProtoReflect.Descriptor instead.
 func (*UserInput_DownloadedFiles) Descriptor() ([]byte, []int) {
-	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{1, 6}
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{1, 8}
+}
+
+type UserInput_FormResponse_Field struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // The `name` of the form field answered.
+	// Types that are assignable to Entry:
+	//	*UserInput_FormResponse_Field_TextInput
+	//	*UserInput_FormResponse_Field_Selection
+	//	*UserInput_FormResponse_Field_Confirmation
+	Entry isUserInput_FormResponse_Field_Entry `protobuf_oneof:"entry"`
+}
+
+func (x *UserInput_FormResponse_Field) Reset() {
+	*x = UserInput_FormResponse_Field{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserInput_FormResponse_Field) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserInput_FormResponse_Field) ProtoMessage() {}
+
+func (x *UserInput_FormResponse_Field) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserInput_FormResponse_Field.ProtoReflect.Descriptor instead.
+func (*UserInput_FormResponse_Field) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{1, 7, 0}
+}
+
+func (x *UserInput_FormResponse_Field) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *UserInput_FormResponse_Field) GetEntry() isUserInput_FormResponse_Field_Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (x *UserInput_FormResponse_Field) GetTextInput() *UserInput_TextInput {
+	if x, ok := x.GetEntry().(*UserInput_FormResponse_Field_TextInput); ok {
+		return x.TextInput
+	}
+	return nil
+}
+
+func (x *UserInput_FormResponse_Field) GetSelection() *UserInput_Selection {
+	if x, ok := x.GetEntry().(*UserInput_FormResponse_Field_Selection); ok {
+		return x.Selection
+	}
+	return nil
+}
+
+func (x *UserInput_FormResponse_Field) GetConfirmation() *UserInput_Confirmation {
+	if x, ok := x.GetEntry().(*UserInput_FormResponse_Field_Confirmation); ok {
+		return x.Confirmation
+	}
+	return nil
+}
+
+type isUserInput_FormResponse_Field_Entry interface {
+	isUserInput_FormResponse_Field_Entry()
+}
+
+type UserInput_FormResponse_Field_TextInput struct {
+	TextInput *UserInput_TextInput `protobuf:"bytes,2,opt,name=text_input,json=textInput,proto3,oneof"`
 }
 
+type UserInput_FormResponse_Field_Selection struct {
+	Selection *UserInput_Selection `protobuf:"bytes,3,opt,name=selection,proto3,oneof"`
+}
+
+type UserInput_FormResponse_Field_Confirmation struct {
+	Confirmation *UserInput_Confirmation `protobuf:"bytes,4,opt,name=confirmation,proto3,oneof"`
+}
+
+func (*UserInput_FormResponse_Field_TextInput) isUserInput_FormResponse_Field_Entry() {}
+
+func (*UserInput_FormResponse_Field_Selection) isUserInput_FormResponse_Field_Entry() {}
+
+func (*UserInput_FormResponse_Field_Confirmation) isUserInput_FormResponse_Field_Entry() {}
+
 type SystemOutput_Message struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1000,7 +1304,7 @@ type SystemOutput_Message struct {
 func (x *SystemOutput_Message) Reset() {
 	*x = SystemOutput_Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[12]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1013,7 +1317,7 @@ func (x *SystemOutput_Message) String() string {
 func (*SystemOutput_Message) ProtoMessage() {}
 
 func (x *SystemOutput_Message) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[12]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,7 +1359,7 @@ type SystemOutput_ImageWithText struct {
 func (x *SystemOutput_ImageWithText) Reset() {
 	*x = SystemOutput_ImageWithText{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[13]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1068,7 +1372,7 @@ func (x *SystemOutput_ImageWithText) String() string {
 func (*SystemOutput_ImageWithText) ProtoMessage() {}
 
 func (x *SystemOutput_ImageWithText) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[13]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1112,12 +1416,13 @@ type SystemOutput_ListSelect struct {
 	SelectType        SystemOutput_ListSelect_SelectType `protobuf:"varint,7,opt,name=select_type,json=selectType,proto3,enum=sf.codegen.conversation.v1.SystemOutput_ListSelect_SelectType" json:"select_type,omitempty"`
 	SelectButtonLabel string                             `protobuf:"bytes,5,opt,name=select_button_label,json=selectButtonLabel,proto3" json:"select_button_label,omitempty"`
 	DefaultValue      string                             `protobuf:"bytes,9,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	DefaultValues     []string                           `protobuf:"bytes,10,rep,name=default_values,json=defaultValues,proto3" json:"default_values,omitempty"` // Pre-selected values of a `select_many` list.
 }
 
 func (x *SystemOutput_ListSelect) Reset() {
 	*x = SystemOutput_ListSelect{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[14]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1130,7 +1435,7 @@ func (x *SystemOutput_ListSelect) String() string {
 func (*SystemOutput_ListSelect) ProtoMessage() {}
 
 func (x *SystemOutput_ListSelect) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[14]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1209,6 +1514,13 @@ func (x *SystemOutput_ListSelect) GetDefaultValue() string {
 	return ""
 }
 
+func (x *SystemOutput_ListSelect) GetDefaultValues() []string {
+	if x != nil {
+		return x.DefaultValues
+	}
+	return nil
+}
+
 type SystemOutput_TextInput struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1228,7 +1540,7 @@ type SystemOutput_TextInput struct {
 func (x *SystemOutput_TextInput) Reset() {
 	*x = SystemOutput_TextInput{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[15]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1241,7 +1553,7 @@ func (x *SystemOutput_TextInput) String() string {
 func (*SystemOutput_TextInput) ProtoMessage() {}
 
 func (x *SystemOutput_TextInput) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[15]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1320,32 +1632,36 @@ func (x *SystemOutput_TextInput) GetSubmitButtonIcon() string {
 	return ""
 }
 
-type SystemOutput_Loading struct {
+// Upload asks for a file, answered with a `UserInput.Upload`.
+type SystemOutput_Upload struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Loading bool   `protobuf:"varint,1,opt,name=loading,proto3" json:"loading,omitempty"`
-	Label   string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"` // other fields to format the loader and whatnot
+	Prompt            string   `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Description       string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`                                        // Markdown
+	AcceptedMimeTypes []string `protobuf:"bytes,3,rep,name=accepted_mime_types,json=acceptedMimeTypes,proto3" json:"accepted_mime_types,omitempty"` // ex: "application/json", or "image/*". Any file is accepted when empty.
+	MaxSize           uint64   `protobuf:"varint,4,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`                                // In bytes, no limit when 0.
+	SubmitButtonLabel string   `protobuf:"bytes,5,opt,name=submit_button_label,json=submitButtonLabel,proto3" json:"submit_button_label,omitempty"`
 }
 
-func (x *SystemOutput_Loading) Reset() {
-	*x = SystemOutput_Loading{}
+func (x *SystemOutput_Upload) Reset() {
+	*x = SystemOutput_Upload{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[16]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SystemOutput_Loading) String() string {
+func (x *SystemOutput_Upload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemOutput_Loading) ProtoMessage() {}
+func (*SystemOutput_Upload) ProtoMessage() {}
 
-func (x *SystemOutput_Loading) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[16]
+func (x *SystemOutput_Upload) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1356,37 +1672,370 @@ func (x *SystemOutput_Loading) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemOutput_Loading.ProtoReflect.Descriptor instead.
-func (*SystemOutput_Loading) Descriptor() ([]byte, []int) {
+// Deprecated: Use SystemOutput_Upload.ProtoReflect.Descriptor instead.
+func (*SystemOutput_Upload) Descriptor() ([]byte, []int) {
 	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 4}
 }
 
-func (x *SystemOutput_Loading) GetLoading() bool {
+func (x *SystemOutput_Upload) GetPrompt() string {
 	if x != nil {
-		return x.Loading
+		return x.Prompt
 	}
-	return false
+	return ""
 }
 
-func (x *SystemOutput_Loading) GetLabel() string {
+func (x *SystemOutput_Upload) GetDescription() string {
 	if x != nil {
-		return x.Label
+		return x.Description
 	}
 	return ""
 }
 
-type SystemOutput_DownloadFiles struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *SystemOutput_Upload) GetAcceptedMimeTypes() []string {
+	if x != nil {
+		return x.AcceptedMimeTypes
+	}
+	return nil
+}
 
-	Files []*SystemOutput_DownloadFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+func (x *SystemOutput_Upload) GetMaxSize() uint64 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
 }
 
-func (x *SystemOutput_DownloadFiles) Reset() {
-	*x = SystemOutput_DownloadFiles{}
+func (x *SystemOutput_Upload) GetSubmitButtonLabel() string {
+	if x != nil {
+		return x.SubmitButtonLabel
+	}
+	return ""
+}
+
+// Form asks several related fields at once, answered with a `UserInput.FormResponse`.
+// Each field carries its own validation, exactly like the standalone prompt of the same type.
+type SystemOutput_Form struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prompt            string                     `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Description       string                     `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"` // Markdown
+	Fields            []*SystemOutput_Form_Field `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	SubmitButtonLabel string                     `protobuf:"bytes,4,opt,name=submit_button_label,json=submitButtonLabel,proto3" json:"submit_button_label,omitempty"`
+}
+
+func (x *SystemOutput_Form) Reset() {
+	*x = SystemOutput_Form{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[17]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOutput_Form) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOutput_Form) ProtoMessage() {}
+
+func (x *SystemOutput_Form) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOutput_Form.ProtoReflect.Descriptor instead.
+func (*SystemOutput_Form) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *SystemOutput_Form) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *SystemOutput_Form) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SystemOutput_Form) GetFields() []*SystemOutput_Form_Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SystemOutput_Form) GetSubmitButtonLabel() string {
+	if x != nil {
+		return x.SubmitButtonLabel
+	}
+	return ""
+}
+
+// Table displays rows of values, ex: the fields of each event of an ABI.
+type SystemOutput_Table struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title   string                    `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Columns []string                  `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows    []*SystemOutput_Table_Row `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"` // Each row has as many cells as there are columns.
+}
+
+func (x *SystemOutput_Table) Reset() {
+	*x = SystemOutput_Table{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOutput_Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOutput_Table) ProtoMessage() {}
+
+func (x *SystemOutput_Table) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOutput_Table.ProtoReflect.Descriptor instead.
+func (*SystemOutput_Table) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 6}
+}
+
+func (x *SystemOutput_Table) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SystemOutput_Table) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *SystemOutput_Table) GetRows() []*SystemOutput_Table_Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// Code displays a snippet of source code, to be highlighted according to its language.
+type SystemOutput_Code struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title    string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"` // ex: "protobuf", "rust", "json"
+	Content  string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *SystemOutput_Code) Reset() {
+	*x = SystemOutput_Code{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOutput_Code) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOutput_Code) ProtoMessage() {}
+
+func (x *SystemOutput_Code) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOutput_Code.ProtoReflect.Descriptor instead.
+func (*SystemOutput_Code) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 7}
+}
+
+func (x *SystemOutput_Code) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SystemOutput_Code) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SystemOutput_Code) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// FileTree displays a list of files, ex: the files of a generated project.
+type SystemOutput_FileTree struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Title string                        `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Files []*SystemOutput_FileTree_File `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *SystemOutput_FileTree) Reset() {
+	*x = SystemOutput_FileTree{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOutput_FileTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOutput_FileTree) ProtoMessage() {}
+
+func (x *SystemOutput_FileTree) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOutput_FileTree.ProtoReflect.Descriptor instead.
+func (*SystemOutput_FileTree) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 8}
+}
+
+func (x *SystemOutput_FileTree) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SystemOutput_FileTree) GetFiles() []*SystemOutput_FileTree_File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type SystemOutput_Loading struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Loading bool   `protobuf:"varint,1,opt,name=loading,proto3" json:"loading,omitempty"`
+	Label   string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"` // other fields to format the loader and whatnot
+}
+
+func (x *SystemOutput_Loading) Reset() {
+	*x = SystemOutput_Loading{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOutput_Loading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOutput_Loading) ProtoMessage() {}
+
+func (x *SystemOutput_Loading) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOutput_Loading.ProtoReflect.Descriptor instead.
+func (*SystemOutput_Loading) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 9}
+}
+
+func (x *SystemOutput_Loading) GetLoading() bool {
+	if x != nil {
+		return x.Loading
+	}
+	return false
+}
+
+func (x *SystemOutput_Loading) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type SystemOutput_DownloadFiles struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Files []*SystemOutput_DownloadFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *SystemOutput_DownloadFiles) Reset() {
+	*x = SystemOutput_DownloadFiles{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1399,7 +2048,7 @@ func (x *SystemOutput_DownloadFiles) String() string {
 func (*SystemOutput_DownloadFiles) ProtoMessage() {}
 
 func (x *SystemOutput_DownloadFiles) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[17]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1412,7 +2061,7 @@ func (x *SystemOutput_DownloadFiles) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemOutput_DownloadFiles.ProtoReflect.Descriptor instead.
 func (*SystemOutput_DownloadFiles) Descriptor() ([]byte, []int) {
-	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 5}
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 10}
 }
 
 func (x *SystemOutput_DownloadFiles) GetFiles() []*SystemOutput_DownloadFile {
@@ -1436,7 +2085,7 @@ type SystemOutput_DownloadFile struct {
 func (x *SystemOutput_DownloadFile) Reset() {
 	*x = SystemOutput_DownloadFile{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[18]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1449,7 +2098,7 @@ func (x *SystemOutput_DownloadFile) String() string {
 func (*SystemOutput_DownloadFile) ProtoMessage() {}
 
 func (x *SystemOutput_DownloadFile) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[18]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1462,7 +2111,7 @@ func (x *SystemOutput_DownloadFile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemOutput_DownloadFile.ProtoReflect.Descriptor instead.
 func (*SystemOutput_DownloadFile) Descriptor() ([]byte, []int) {
-	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 6}
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 11}
 }
 
 func (x *SystemOutput_DownloadFile) GetFilename() string {
@@ -1508,7 +2157,7 @@ type SystemOutput_Confirm struct {
 func (x *SystemOutput_Confirm) Reset() {
 	*x = SystemOutput_Confirm{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[19]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1521,7 +2170,7 @@ func (x *SystemOutput_Confirm) String() string {
 func (*SystemOutput_Confirm) ProtoMessage() {}
 
 func (x *SystemOutput_Confirm) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[19]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1534,42 +2183,246 @@ func (x *SystemOutput_Confirm) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemOutput_Confirm.ProtoReflect.Descriptor instead.
 func (*SystemOutput_Confirm) Descriptor() ([]byte, []int) {
-	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 7}
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 12}
 }
 
 func (x *SystemOutput_Confirm) GetPrompt() string {
 	if x != nil {
 		return x.Prompt
 	}
-	return ""
+	return ""
+}
+
+func (x *SystemOutput_Confirm) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SystemOutput_Confirm) GetAcceptButtonLabel() string {
+	if x != nil {
+		return x.AcceptButtonLabel
+	}
+	return ""
+}
+
+func (x *SystemOutput_Confirm) GetDeclineButtonLabel() string {
+	if x != nil {
+		return x.DeclineButtonLabel
+	}
+	return ""
+}
+
+func (x *SystemOutput_Confirm) GetDefaultButton() SystemOutput_Confirm_Button {
+	if x != nil {
+		return x.DefaultButton
+	}
+	return SystemOutput_Confirm_UNSET
+}
+
+type SystemOutput_Form_Field struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Identifies the field in the `UserInput.FormResponse`.
+	// Types that are assignable to Entry:
+	//	*SystemOutput_Form_Field_TextInput
+	//	*SystemOutput_Form_Field_ListSelect
+	//	*SystemOutput_Form_Field_Confirm
+	Entry isSystemOutput_Form_Field_Entry `protobuf_oneof:"entry"`
+}
+
+func (x *SystemOutput_Form_Field) Reset() {
+	*x = SystemOutput_Form_Field{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOutput_Form_Field) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOutput_Form_Field) ProtoMessage() {}
+
+func (x *SystemOutput_Form_Field) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOutput_Form_Field.ProtoReflect.Descriptor instead.
+func (*SystemOutput_Form_Field) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 5, 0}
+}
+
+func (x *SystemOutput_Form_Field) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *SystemOutput_Form_Field) GetEntry() isSystemOutput_Form_Field_Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (x *SystemOutput_Form_Field) GetTextInput() *SystemOutput_TextInput {
+	if x, ok := x.GetEntry().(*SystemOutput_Form_Field_TextInput); ok {
+		return x.TextInput
+	}
+	return nil
+}
+
+func (x *SystemOutput_Form_Field) GetListSelect() *SystemOutput_ListSelect {
+	if x, ok := x.GetEntry().(*SystemOutput_Form_Field_ListSelect); ok {
+		return x.ListSelect
+	}
+	return nil
+}
+
+func (x *SystemOutput_Form_Field) GetConfirm() *SystemOutput_Confirm {
+	if x, ok := x.GetEntry().(*SystemOutput_Form_Field_Confirm); ok {
+		return x.Confirm
+	}
+	return nil
+}
+
+type isSystemOutput_Form_Field_Entry interface {
+	isSystemOutput_Form_Field_Entry()
+}
+
+type SystemOutput_Form_Field_TextInput struct {
+	TextInput *SystemOutput_TextInput `protobuf:"bytes,2,opt,name=text_input,json=textInput,proto3,oneof"`
+}
+
+type SystemOutput_Form_Field_ListSelect struct {
+	ListSelect *SystemOutput_ListSelect `protobuf:"bytes,3,opt,name=list_select,json=listSelect,proto3,oneof"`
+}
+
+type SystemOutput_Form_Field_Confirm struct {
+	Confirm *SystemOutput_Confirm `protobuf:"bytes,4,opt,name=confirm,proto3,oneof"`
+}
+
+func (*SystemOutput_Form_Field_TextInput) isSystemOutput_Form_Field_Entry() {}
+
+func (*SystemOutput_Form_Field_ListSelect) isSystemOutput_Form_Field_Entry() {}
+
+func (*SystemOutput_Form_Field_Confirm) isSystemOutput_Form_Field_Entry() {}
+
+type SystemOutput_Table_Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cells []string `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *SystemOutput_Table_Row) Reset() {
+	*x = SystemOutput_Table_Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemOutput_Table_Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemOutput_Table_Row) ProtoMessage() {}
+
+func (x *SystemOutput_Table_Row) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemOutput_Table_Row.ProtoReflect.Descriptor instead.
+func (*SystemOutput_Table_Row) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 6, 0}
+}
+
+func (x *SystemOutput_Table_Row) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type SystemOutput_FileTree_File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`  // Slash-separated, relative to the root of the tree.
+	Size uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` // In bytes.
+}
+
+func (x *SystemOutput_FileTree_File) Reset() {
+	*x = SystemOutput_FileTree_File{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *SystemOutput_Confirm) GetDescription() string {
-	if x != nil {
-		return x.Description
-	}
-	return ""
+func (x *SystemOutput_FileTree_File) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *SystemOutput_Confirm) GetAcceptButtonLabel() string {
-	if x != nil {
-		return x.AcceptButtonLabel
+func (*SystemOutput_FileTree_File) ProtoMessage() {}
+
+func (x *SystemOutput_FileTree_File) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SystemOutput_Confirm) GetDeclineButtonLabel() string {
+// Deprecated: Use SystemOutput_FileTree_File.ProtoReflect.Descriptor instead.
+func (*SystemOutput_FileTree_File) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{2, 8, 0}
+}
+
+func (x *SystemOutput_FileTree_File) GetPath() string {
 	if x != nil {
-		return x.DeclineButtonLabel
+		return x.Path
 	}
 	return ""
 }
 
-func (x *SystemOutput_Confirm) GetDefaultButton() SystemOutput_Confirm_Button {
+func (x *SystemOutput_FileTree_File) GetSize() uint64 {
 	if x != nil {
-		return x.DefaultButton
+		return x.Size
 	}
-	return SystemOutput_Confirm_UNSET
+	return 0
 }
 
 type DiscoveryResponse_Generator struct {
@@ -1582,12 +2435,14 @@ type DiscoveryResponse_Generator struct {
 	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	IconUrl     string `protobuf:"bytes,4,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
 	Endpoint    string `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"` // if not the same as this one
+	// The prompts this generator can ask, identified by the `action_id` set on them.
+	Actions []*DiscoveryResponse_Action `protobuf:"bytes,6,rep,name=actions,proto3" json:"actions,omitempty"`
 }
 
 func (x *DiscoveryResponse_Generator) Reset() {
 	*x = DiscoveryResponse_Generator{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[20]
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1600,7 +2455,7 @@ func (x *DiscoveryResponse_Generator) String() string {
 func (*DiscoveryResponse_Generator) ProtoMessage() {}
 
 func (x *DiscoveryResponse_Generator) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[20]
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1651,6 +2506,68 @@ func (x *DiscoveryResponse_Generator) GetEndpoint() string {
 	return ""
 }
 
+func (x *DiscoveryResponse_Generator) GetActions() []*DiscoveryResponse_Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+type DiscoveryResponse_Action struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // ex: "evm-events-calls.contract_address", stable across changes to the prompt wording.
+	Input string `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"` // The UserInput entry answering the prompt: "text_input", "selection", "confirmation", "file" or "form_response".
+}
+
+func (x *DiscoveryResponse_Action) Reset() {
+	*x = DiscoveryResponse_Action{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiscoveryResponse_Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscoveryResponse_Action) ProtoMessage() {}
+
+func (x *DiscoveryResponse_Action) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_codegen_conversation_v1_conversation_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscoveryResponse_Action.ProtoReflect.Descriptor instead.
+func (*DiscoveryResponse_Action) Descriptor() ([]byte, []int) {
+	return file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *DiscoveryResponse_Action) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DiscoveryResponse_Action) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
 var File_sf_codegen_conversation_v1_conversation_proto protoreflect.FileDescriptor
 
 var file_sf_codegen_conversation_v1_conversation_proto_rawDesc = []byte{
@@ -1659,7 +2576,7 @@ var file_sf_codegen_conversation_v1_conversation_proto_rawDesc = []byte{
 	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x1a, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76,
 	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x22, 0x07, 0x0a, 0x05, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x83, 0x09, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0xdf, 0x0d, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70,
 	0x75, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0d, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x66, 0x72, 0x6f,
 	0x6d, 0x5f, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
@@ -1696,218 +2613,356 @@ var file_sf_codegen_conversation_v1_conversation_proto_rawDesc = []byte{
 	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65,
 	0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x65,
 	0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0x21, 0x0a, 0x09, 0x54, 0x65, 0x78,
-	0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x8d, 0x01, 0x0a,
-	0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
-	0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x47, 0x0a, 0x07, 0x68, 0x79, 0x64,
-	0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x73, 0x66, 0x2e,
-	0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75,
-	0x74, 0x2e, 0x48, 0x79, 0x64, 0x72, 0x61, 0x74, 0x65, 0x52, 0x07, 0x68, 0x79, 0x64, 0x72, 0x61,
-	0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x97, 0x01, 0x0a,
-	0x07, 0x48, 0x79, 0x64, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x76, 0x65,
-	0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
-	0x61, 0x76, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f,
-	0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6c, 0x61,
-	0x73, 0x74, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x65, 0x74,
-	0x5f, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x11, 0x72, 0x65, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
-	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x5b, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
-	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x1a, 0x37, 0x0a, 0x09, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x30, 0x0a, 0x0c,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b,
-	0x61, 0x66, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0b, 0x61, 0x66, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x76, 0x65, 0x1a, 0x11,
-	0x0a, 0x0f, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
-	0x73, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0xd8, 0x11, 0x0a, 0x0c, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6d,
-	0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x73, 0x67,
-	0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6d, 0x73, 0x67, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x4d, 0x73, 0x67,
-	0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
-	0x73, 0x74, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x4c,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x30, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e,
+	0x61, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x04, 0x62, 0x61, 0x63,
+	0x6b, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64,
+	0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x42,
+	0x61, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x59, 0x0a, 0x0d, 0x66,
+	0x6f, 0x72, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e,
+	0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x21, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x8d, 0x01, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x47, 0x0a, 0x07, 0x68, 0x79, 0x64, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64,
+	0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x48,
+	0x79, 0x64, 0x72, 0x61, 0x74, 0x65, 0x52, 0x07, 0x68, 0x79, 0x64, 0x72, 0x61, 0x74, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x97, 0x01, 0x0a, 0x07, 0x48, 0x79,
+	0x64, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x76, 0x65, 0x64, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x61, 0x76, 0x65,
+	0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d, 0x73, 0x67,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x4d,
+	0x73, 0x67, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x63, 0x6f,
+	0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x72, 0x65, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x1a, 0x5b, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69,
+	0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x1a, 0x67, 0x0a, 0x09, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x30, 0x0a, 0x0c, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x66, 0x66,
+	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x61, 0x66, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x76, 0x65, 0x1a, 0x06, 0x0a, 0x04, 0x42,
+	0x61, 0x63, 0x6b, 0x1a, 0x84, 0x03, 0x0a, 0x0c, 0x46, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65,
+	0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e, 0x46, 0x6f, 0x72, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0xa1, 0x02, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x50, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f,
+	0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x2e,
+	0x54, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x74, 0x65, 0x78,
+	0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x4f, 0x0a, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x58, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e,
+	0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x1a, 0x11, 0x0a, 0x0f, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x07, 0x0a,
+	0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0xa4, 0x1d, 0x0a, 0x0c, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x67, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1e,
+	0x0a, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6d, 0x73, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x4d, 0x73, 0x67, 0x49, 0x64, 0x12, 0x1b,
+	0x0a, 0x09, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x4c, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x73, 0x66,
+	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x36, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x57, 0x69, 0x74, 0x68, 0x54, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x57, 0x69, 0x74, 0x68, 0x54, 0x65, 0x78, 0x74, 0x12, 0x56, 0x0a, 0x0b, 0x6c, 0x69,
+	0x73, 0x74, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x33, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e,
 	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x60, 0x0a, 0x0f,
-	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18,
-	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67,
+	0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x12, 0x53, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65,
+	0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x2e, 0x54, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x74, 0x65,
+	0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x4c, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f,
+	0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x4c, 0x0a, 0x07, 0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65,
+	0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x07, 0x6c, 0x6f, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x5f, 0x0a, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x73, 0x66,
+	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x12, 0x49, 0x0a, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65,
+	0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x43, 0x0a, 0x04, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x48, 0x00, 0x52, 0x04,
+	0x66, 0x6f, 0x72, 0x6d, 0x12, 0x46, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e,
+	0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x43, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x73, 0x66, 0x2e,
+	0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x48, 0x00, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x50, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x18, 0x1a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65,
+	0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x46,
+	0x69, 0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x48, 0x00, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54,
+	0x72, 0x65, 0x65, 0x1a, 0x3b, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x79, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65,
+	0x1a, 0x44, 0x0a, 0x0d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x57, 0x69, 0x74, 0x68, 0x54, 0x65, 0x78,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61,
+	0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61,
+	0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x1a, 0xb6, 0x03, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x75,
+	0x72, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x55, 0x72, 0x6c, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x5f, 0x0a, 0x0b, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3e,
+	0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x5f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x42,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x0a, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x52, 0x4f, 0x50, 0x44, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x55, 0x54, 0x54, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x1a,
+	0xf0, 0x02, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x2b, 0x0a,
+	0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x67, 0x65, 0x78, 0x70, 0x12, 0x38, 0x0a, 0x18, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x49, 0x63,
+	0x6f, 0x6e, 0x1a, 0xbd, 0x01, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x65, 0x70,
+	0x74, 0x65, 0x64, 0x5f, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x4d, 0x69,
+	0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x62, 0x75, 0x74,
+	0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x1a, 0xdf, 0x03, 0x0a, 0x04, 0x46, 0x6f, 0x72, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67,
 	0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
 	0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x57, 0x69, 0x74, 0x68, 0x54, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52,
-	0x0d, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x57, 0x69, 0x74, 0x68, 0x54, 0x65, 0x78, 0x74, 0x12, 0x56,
-	0x0a, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x11, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e,
-	0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x6c, 0x69, 0x73, 0x74,
-	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x53, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69,
-	0x6e, 0x70, 0x75, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73, 0x66, 0x2e,
+	0x46, 0x6f, 0x72, 0x6d, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x62, 0x75, 0x74,
+	0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x1a, 0x9f, 0x02, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x53, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65,
+	0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x54,
+	0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x56, 0x0a, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x73, 0x66, 0x2e,
 	0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
 	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00,
-	0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x4c, 0x0a, 0x07, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x73,
+	0x74, 0x70, 0x75, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x48,
+	0x00, 0x52, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x4c, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30,
+	0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x42, 0x07, 0x0a, 0x05, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x1a, 0x9c, 0x01, 0x0a, 0x05, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x46,
+	0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73,
 	0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
 	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x48, 0x00,
-	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x4c, 0x0a, 0x07, 0x6c, 0x6f, 0x61,
-	0x64, 0x69, 0x6e, 0x67, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x73, 0x66, 0x2e,
-	0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x07,
-	0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x5f, 0x0a, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x36, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73,
-	0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0d, 0x64, 0x6f, 0x77, 0x6e, 0x6c,
-	0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0x3b, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12,
-	0x14, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x73, 0x74, 0x79, 0x6c, 0x65, 0x1a, 0x44, 0x0a, 0x0d, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x57, 0x69,
-	0x74, 0x68, 0x54, 0x65, 0x78, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x6d, 0x67, 0x5f, 0x75, 0x72,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6d, 0x67, 0x55, 0x72, 0x6c, 0x12,
-	0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x1a, 0x8f, 0x03, 0x0a, 0x0a,
-	0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
-	0x69, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6e, 0x73,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a,
-	0x0b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x6d, 0x61, 0x6e, 0x79, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x5f,
-	0x0a, 0x0b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x3e, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x52, 0x6f, 0x77,
+	0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x1a, 0x1b, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x65,
+	0x6c, 0x6c, 0x73, 0x1a, 0x52, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x9e, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65,
+	0x54, 0x72, 0x65, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x4c, 0x0a, 0x05, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x73, 0x66, 0x2e, 0x63,
+	0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x65, 0x65, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0x2e, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x1a, 0x39, 0x0a, 0x07, 0x4c, 0x6f, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x1a, 0x5c, 0x0a, 0x0d, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x12, 0x4b, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e,
 	0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x2e, 0x0a, 0x13, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e,
-	0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
-	0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x22, 0x27, 0x0a, 0x0a, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x52, 0x4f, 0x50, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x00,
-	0x12, 0x0b, 0x0a, 0x07, 0x42, 0x55, 0x54, 0x54, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x1a, 0xf0, 0x02,
-	0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70,
-	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x6f,
-	0x6d, 0x70, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x68, 0x6f,
-	0x6c, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x63,
-	0x65, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75,
-	0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x70,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x67, 0x65, 0x78, 0x70, 0x12, 0x38, 0x0a, 0x18, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x62, 0x75, 0x74,
-	0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x11, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f, 0x62, 0x75, 0x74,
-	0x74, 0x6f, 0x6e, 0x5f, 0x69, 0x63, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
-	0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x49, 0x63, 0x6f, 0x6e,
-	0x1a, 0x39, 0x0a, 0x07, 0x4c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x6c,
-	0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6c, 0x6f,
-	0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x1a, 0x5c, 0x0a, 0x0d, 0x44,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x4b, 0x0a, 0x05,
-	0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x73, 0x66,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x1a, 0x7a, 0x0a, 0x0c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xb4, 0x02,
+	0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x62, 0x75,
+	0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x62,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x5e, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x5f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x37, 0x2e,
+	0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
+	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x2e,
+	0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42,
+	0x75, 0x74, 0x74, 0x6f, 0x6e, 0x22, 0x2d, 0x0a, 0x06, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x12,
+	0x09, 0x0a, 0x05, 0x55, 0x4e, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f,
+	0x4e, 0x46, 0x49, 0x52, 0x4d, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x43, 0x4c, 0x49,
+	0x4e, 0x45, 0x10, 0x02, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x35, 0x0a,
+	0x10, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x74, 0x65, 0x72, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54,
+	0x65, 0x72, 0x6d, 0x73, 0x22, 0xf9, 0x02, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0a, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37,
+	0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x1a, 0xda, 0x01, 0x0a, 0x09, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x63, 0x6f,
+	0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x63, 0x6f,
+	0x6e, 0x55, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x4e, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x34, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63,
+	0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x2e, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x32, 0xdf, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x65, 0x12, 0x25, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65,
+	0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x1a, 0x28, 0x2e, 0x73, 0x66,
 	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f,
-	0x75, 0x74, 0x70, 0x75, 0x74, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x1a, 0x7a, 0x0a, 0x0c, 0x44, 0x6f, 0x77,
-	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c,
-	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xb4, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
-	0x6d, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61,
-	0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
-	0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x64,
-	0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x5f, 0x6c, 0x61,
-	0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x63, 0x6c, 0x69,
-	0x6e, 0x65, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x5e, 0x0a,
-	0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x37, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x28, 0x01, 0x30, 0x01, 0x12, 0x67, 0x0a, 0x08, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x2c, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67,
 	0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x2e,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x2e, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x52, 0x0d,
-	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x22, 0x2d, 0x0a,
-	0x06, 0x42, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x53, 0x45, 0x54,
-	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x10, 0x01, 0x12,
-	0x0b, 0x0a, 0x07, 0x44, 0x45, 0x43, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x02, 0x42, 0x07, 0x0a, 0x05,
-	0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x35, 0x0a, 0x10, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
-	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x72, 0x6d, 0x73, 0x22, 0xf9, 0x01, 0x0a,
-	0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x57, 0x0a, 0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65,
-	0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52,
-	0x0a, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x1a, 0x8a, 0x01, 0x0a, 0x09,
-	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74,
-	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12,
-	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x63, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x63, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
-	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x32, 0xdf, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6e,
-	0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x5f, 0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x25, 0x2e, 0x73,
-	0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72,
-	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e,
-	0x70, 0x75, 0x74, 0x1a, 0x28, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e,
+	0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e,
 	0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x28, 0x01, 0x30,
-	0x01, 0x12, 0x67, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x2c, 0x2e,
-	0x73, 0x66, 0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65,
-	0x72, 0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
-	0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x73, 0x66,
-	0x2e, 0x63, 0x6f, 0x64, 0x65, 0x67, 0x65, 0x6e, 0x2e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
-	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1923,7 +2978,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_rawDescGZIP() []byte {
 }
 
 var file_sf_codegen_conversation_v1_conversation_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_sf_codegen_conversation_v1_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_sf_codegen_conversation_v1_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_sf_codegen_conversation_v1_conversation_proto_goTypes = []any{
 	(SystemOutput_ListSelect_SelectType)(0), // 0: sf.codegen.conversation.v1.SystemOutput.ListSelect.SelectType
 	(SystemOutput_Confirm_Button)(0),        // 1: sf.codegen.conversation.v1.SystemOutput.Confirm.Button
@@ -1938,16 +2993,28 @@ var file_sf_codegen_conversation_v1_conversation_proto_goTypes = []any{
 	(*UserInput_Upload)(nil),                // 10: sf.codegen.conversation.v1.UserInput.Upload
 	(*UserInput_Selection)(nil),             // 11: sf.codegen.conversation.v1.UserInput.Selection
 	(*UserInput_Confirmation)(nil),          // 12: sf.codegen.conversation.v1.UserInput.Confirmation
-	(*UserInput_DownloadedFiles)(nil),       // 13: sf.codegen.conversation.v1.UserInput.DownloadedFiles
-	(*SystemOutput_Message)(nil),            // 14: sf.codegen.conversation.v1.SystemOutput.Message
-	(*SystemOutput_ImageWithText)(nil),      // 15: sf.codegen.conversation.v1.SystemOutput.ImageWithText
-	(*SystemOutput_ListSelect)(nil),         // 16: sf.codegen.conversation.v1.SystemOutput.ListSelect
-	(*SystemOutput_TextInput)(nil),          // 17: sf.codegen.conversation.v1.SystemOutput.TextInput
-	(*SystemOutput_Loading)(nil),            // 18: sf.codegen.conversation.v1.SystemOutput.Loading
-	(*SystemOutput_DownloadFiles)(nil),      // 19: sf.codegen.conversation.v1.SystemOutput.DownloadFiles
-	(*SystemOutput_DownloadFile)(nil),       // 20: sf.codegen.conversation.v1.SystemOutput.DownloadFile
-	(*SystemOutput_Confirm)(nil),            // 21: sf.codegen.conversation.v1.SystemOutput.Confirm
-	(*DiscoveryResponse_Generator)(nil),     // 22: sf.codegen.conversation.v1.DiscoveryResponse.Generator
+	(*UserInput_Back)(nil),                  // 13: sf.codegen.conversation.v1.UserInput.Back
+	(*UserInput_FormResponse)(nil),          // 14: sf.codegen.conversation.v1.UserInput.FormResponse
+	(*UserInput_DownloadedFiles)(nil),       // 15: sf.codegen.conversation.v1.UserInput.DownloadedFiles
+	(*UserInput_FormResponse_Field)(nil),    // 16: sf.codegen.conversation.v1.UserInput.FormResponse.Field
+	(*SystemOutput_Message)(nil),            // 17: sf.codegen.conversation.v1.SystemOutput.Message
+	(*SystemOutput_ImageWithText)(nil),      // 18: sf.codegen.conversation.v1.SystemOutput.ImageWithText
+	(*SystemOutput_ListSelect)(nil),         // 19: sf.codegen.conversation.v1.SystemOutput.ListSelect
+	(*SystemOutput_TextInput)(nil),          // 20: sf.codegen.conversation.v1.SystemOutput.TextInput
+	(*SystemOutput_Upload)(nil),             // 21: sf.codegen.conversation.v1.SystemOutput.Upload
+	(*SystemOutput_Form)(nil),               // 22: sf.codegen.conversation.v1.SystemOutput.Form
+	(*SystemOutput_Table)(nil),              // 23: sf.codegen.conversation.v1.SystemOutput.Table
+	(*SystemOutput_Code)(nil),               // 24: sf.codegen.conversation.v1.SystemOutput.Code
+	(*SystemOutput_FileTree)(nil),           // 25: sf.codegen.conversation.v1.SystemOutput.FileTree
+	(*SystemOutput_Loading)(nil),            // 26: sf.codegen.conversation.v1.SystemOutput.Loading
+	(*SystemOutput_DownloadFiles)(nil),      // 27: sf.codegen.conversation.v1.SystemOutput.DownloadFiles
+	(*SystemOutput_DownloadFile)(nil),       // 28: sf.codegen.conversation.v1.SystemOutput.DownloadFile
+	(*SystemOutput_Confirm)(nil),            // 29: sf.codegen.conversation.v1.SystemOutput.Confirm
+	(*SystemOutput_Form_Field)(nil),         // 30: sf.codegen.conversation.v1.SystemOutput.Form.Field
+	(*SystemOutput_Table_Row)(nil),          // 31: sf.codegen.conversation.v1.SystemOutput.Table.Row
+	(*SystemOutput_FileTree_File)(nil),      // 32: sf.codegen.conversation.v1.SystemOutput.FileTree.File
+	(*DiscoveryResponse_Generator)(nil),     // 33: sf.codegen.conversation.v1.DiscoveryResponse.Generator
+	(*DiscoveryResponse_Action)(nil),        // 34: sf.codegen.conversation.v1.DiscoveryResponse.Action
 }
 var file_sf_codegen_conversation_v1_conversation_proto_depIdxs = []int32{
 	8,  // 0: sf.codegen.conversation.v1.UserInput.start:type_name -> sf.codegen.conversation.v1.UserInput.Start
@@ -1955,28 +3022,46 @@ var file_sf_codegen_conversation_v1_conversation_proto_depIdxs = []int32{
 	11, // 2: sf.codegen.conversation.v1.UserInput.selection:type_name -> sf.codegen.conversation.v1.UserInput.Selection
 	12, // 3: sf.codegen.conversation.v1.UserInput.confirmation:type_name -> sf.codegen.conversation.v1.UserInput.Confirmation
 	10, // 4: sf.codegen.conversation.v1.UserInput.file:type_name -> sf.codegen.conversation.v1.UserInput.Upload
-	13, // 5: sf.codegen.conversation.v1.UserInput.downloaded_files:type_name -> sf.codegen.conversation.v1.UserInput.DownloadedFiles
-	14, // 6: sf.codegen.conversation.v1.SystemOutput.message:type_name -> sf.codegen.conversation.v1.SystemOutput.Message
-	15, // 7: sf.codegen.conversation.v1.SystemOutput.image_with_text:type_name -> sf.codegen.conversation.v1.SystemOutput.ImageWithText
-	16, // 8: sf.codegen.conversation.v1.SystemOutput.list_select:type_name -> sf.codegen.conversation.v1.SystemOutput.ListSelect
-	17, // 9: sf.codegen.conversation.v1.SystemOutput.text_input:type_name -> sf.codegen.conversation.v1.SystemOutput.TextInput
-	21, // 10: sf.codegen.conversation.v1.SystemOutput.confirm:type_name -> sf.codegen.conversation.v1.SystemOutput.Confirm
-	18, // 11: sf.codegen.conversation.v1.SystemOutput.loading:type_name -> sf.codegen.conversation.v1.SystemOutput.Loading
-	19, // 12: sf.codegen.conversation.v1.SystemOutput.download_files:type_name -> sf.codegen.conversation.v1.SystemOutput.DownloadFiles
-	22, // 13: sf.codegen.conversation.v1.DiscoveryResponse.generators:type_name -> sf.codegen.conversation.v1.DiscoveryResponse.Generator
-	9,  // 14: sf.codegen.conversation.v1.UserInput.Start.hydrate:type_name -> sf.codegen.conversation.v1.UserInput.Hydrate
-	0,  // 15: sf.codegen.conversation.v1.SystemOutput.ListSelect.select_type:type_name -> sf.codegen.conversation.v1.SystemOutput.ListSelect.SelectType
-	20, // 16: sf.codegen.conversation.v1.SystemOutput.DownloadFiles.files:type_name -> sf.codegen.conversation.v1.SystemOutput.DownloadFile
-	1,  // 17: sf.codegen.conversation.v1.SystemOutput.Confirm.default_button:type_name -> sf.codegen.conversation.v1.SystemOutput.Confirm.Button
-	3,  // 18: sf.codegen.conversation.v1.ConversationService.Converse:input_type -> sf.codegen.conversation.v1.UserInput
-	5,  // 19: sf.codegen.conversation.v1.ConversationService.Discover:input_type -> sf.codegen.conversation.v1.DiscoveryRequest
-	4,  // 20: sf.codegen.conversation.v1.ConversationService.Converse:output_type -> sf.codegen.conversation.v1.SystemOutput
-	6,  // 21: sf.codegen.conversation.v1.ConversationService.Discover:output_type -> sf.codegen.conversation.v1.DiscoveryResponse
-	20, // [20:22] is the sub-list for method output_type
-	18, // [18:20] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	15, // 5: sf.codegen.conversation.v1.UserInput.downloaded_files:type_name -> sf.codegen.conversation.v1.UserInput.DownloadedFiles
+	13, // 6: sf.codegen.conversation.v1.UserInput.back:type_name -> sf.codegen.conversation.v1.UserInput.Back
+	14, // 7: sf.codegen.conversation.v1.UserInput.form_response:type_name -> sf.codegen.conversation.v1.UserInput.FormResponse
+	17, // 8: sf.codegen.conversation.v1.SystemOutput.message:type_name -> sf.codegen.conversation.v1.SystemOutput.Message
+	18, // 9: sf.codegen.conversation.v1.SystemOutput.image_with_text:type_name -> sf.codegen.conversation.v1.SystemOutput.ImageWithText
+	19, // 10: sf.codegen.conversation.v1.SystemOutput.list_select:type_name -> sf.codegen.conversation.v1.SystemOutput.ListSelect
+	20, // 11: sf.codegen.conversation.v1.SystemOutput.text_input:type_name -> sf.codegen.conversation.v1.SystemOutput.TextInput
+	29, // 12: sf.codegen.conversation.v1.SystemOutput.confirm:type_name -> sf.codegen.conversation.v1.SystemOutput.Confirm
+	26, // 13: sf.codegen.conversation.v1.SystemOutput.loading:type_name -> sf.codegen.conversation.v1.SystemOutput.Loading
+	27, // 14: sf.codegen.conversation.v1.SystemOutput.download_files:type_name -> sf.codegen.conversation.v1.SystemOutput.DownloadFiles
+	21, // 15: sf.codegen.conversation.v1.SystemOutput.upload:type_name -> sf.codegen.conversation.v1.SystemOutput.Upload
+	22, // 16: sf.codegen.conversation.v1.SystemOutput.form:type_name -> sf.codegen.conversation.v1.SystemOutput.Form
+	23, // 17: sf.codegen.conversation.v1.SystemOutput.table:type_name -> sf.codegen.conversation.v1.SystemOutput.Table
+	24, // 18: sf.codegen.conversation.v1.SystemOutput.code:type_name -> sf.codegen.conversation.v1.SystemOutput.Code
+	25, // 19: sf.codegen.conversation.v1.SystemOutput.file_tree:type_name -> sf.codegen.conversation.v1.SystemOutput.FileTree
+	33, // 20: sf.codegen.conversation.v1.DiscoveryResponse.generators:type_name -> sf.codegen.conversation.v1.DiscoveryResponse.Generator
+	9,  // 21: sf.codegen.conversation.v1.UserInput.Start.hydrate:type_name -> sf.codegen.conversation.v1.UserInput.Hydrate
+	16, // 22: sf.codegen.conversation.v1.UserInput.FormResponse.fields:type_name -> sf.codegen.conversation.v1.UserInput.FormResponse.Field
+	7,  // 23: sf.codegen.conversation.v1.UserInput.FormResponse.Field.text_input:type_name -> sf.codegen.conversation.v1.UserInput.TextInput
+	11, // 24: sf.codegen.conversation.v1.UserInput.FormResponse.Field.selection:type_name -> sf.codegen.conversation.v1.UserInput.Selection
+	12, // 25: sf.codegen.conversation.v1.UserInput.FormResponse.Field.confirmation:type_name -> sf.codegen.conversation.v1.UserInput.Confirmation
+	0,  // 26: sf.codegen.conversation.v1.SystemOutput.ListSelect.select_type:type_name -> sf.codegen.conversation.v1.SystemOutput.ListSelect.SelectType
+	30, // 27: sf.codegen.conversation.v1.SystemOutput.Form.fields:type_name -> sf.codegen.conversation.v1.SystemOutput.Form.Field
+	31, // 28: sf.codegen.conversation.v1.SystemOutput.Table.rows:type_name -> sf.codegen.conversation.v1.SystemOutput.Table.Row
+	32, // 29: sf.codegen.conversation.v1.SystemOutput.FileTree.files:type_name -> sf.codegen.conversation.v1.SystemOutput.FileTree.File
+	28, // 30: sf.codegen.conversation.v1.SystemOutput.DownloadFiles.files:type_name -> sf.codegen.conversation.v1.SystemOutput.DownloadFile
+	1,  // 31: sf.codegen.conversation.v1.SystemOutput.Confirm.default_button:type_name -> sf.codegen.conversation.v1.SystemOutput.Confirm.Button
+	20, // 32: sf.codegen.conversation.v1.SystemOutput.Form.Field.text_input:type_name -> sf.codegen.conversation.v1.SystemOutput.TextInput
+	19, // 33: sf.codegen.conversation.v1.SystemOutput.Form.Field.list_select:type_name -> sf.codegen.conversation.v1.SystemOutput.ListSelect
+	29, // 34: sf.codegen.conversation.v1.SystemOutput.Form.Field.confirm:type_name -> sf.codegen.conversation.v1.SystemOutput.Confirm
+	34, // 35: sf.codegen.conversation.v1.DiscoveryResponse.Generator.actions:type_name -> sf.codegen.conversation.v1.DiscoveryResponse.Action
+	3,  // 36: sf.codegen.conversation.v1.ConversationService.Converse:input_type -> sf.codegen.conversation.v1.UserInput
+	5,  // 37: sf.codegen.conversation.v1.ConversationService.Discover:input_type -> sf.codegen.conversation.v1.DiscoveryRequest
+	4,  // 38: sf.codegen.conversation.v1.ConversationService.Converse:output_type -> sf.codegen.conversation.v1.SystemOutput
+	6,  // 39: sf.codegen.conversation.v1.ConversationService.Discover:output_type -> sf.codegen.conversation.v1.DiscoveryResponse
+	38, // [38:40] is the sub-list for method output_type
+	36, // [36:38] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_sf_codegen_conversation_v1_conversation_proto_init() }
@@ -2118,7 +3203,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*UserInput_DownloadedFiles); i {
+			switch v := v.(*UserInput_Back); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2130,7 +3215,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_Message); i {
+			switch v := v.(*UserInput_FormResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2142,7 +3227,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_ImageWithText); i {
+			switch v := v.(*UserInput_DownloadedFiles); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2154,7 +3239,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[14].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_ListSelect); i {
+			switch v := v.(*UserInput_FormResponse_Field); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2166,7 +3251,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[15].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_TextInput); i {
+			switch v := v.(*SystemOutput_Message); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2178,7 +3263,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[16].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_Loading); i {
+			switch v := v.(*SystemOutput_ImageWithText); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2190,7 +3275,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[17].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_DownloadFiles); i {
+			switch v := v.(*SystemOutput_ListSelect); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2202,7 +3287,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[18].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_DownloadFile); i {
+			switch v := v.(*SystemOutput_TextInput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2214,7 +3299,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[19].Exporter = func(v any, i int) any {
-			switch v := v.(*SystemOutput_Confirm); i {
+			switch v := v.(*SystemOutput_Upload); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2226,6 +3311,138 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			}
 		}
 		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_Form); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_Table); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_Code); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_FileTree); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_Loading); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_DownloadFiles); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_DownloadFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_Confirm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_Form_Field); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_Table_Row); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*SystemOutput_FileTree_File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[31].Exporter = func(v any, i int) any {
 			switch v := v.(*DiscoveryResponse_Generator); i {
 			case 0:
 				return &v.state
@@ -2237,6 +3454,18 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 				return nil
 			}
 		}
+		file_sf_codegen_conversation_v1_conversation_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*DiscoveryResponse_Action); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_sf_codegen_conversation_v1_conversation_proto_msgTypes[1].OneofWrappers = []any{
 		(*UserInput_Start_)(nil),
@@ -2245,6 +3474,8 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 		(*UserInput_Confirmation_)(nil),
 		(*UserInput_File)(nil),
 		(*UserInput_DownloadedFiles_)(nil),
+		(*UserInput_Back_)(nil),
+		(*UserInput_FormResponse_)(nil),
 	}
 	file_sf_codegen_conversation_v1_conversation_proto_msgTypes[2].OneofWrappers = []any{
 		(*SystemOutput_Message_)(nil),
@@ -2254,6 +3485,21 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 		(*SystemOutput_Confirm_)(nil),
 		(*SystemOutput_Loading_)(nil),
 		(*SystemOutput_DownloadFiles_)(nil),
+		(*SystemOutput_Upload_)(nil),
+		(*SystemOutput_Form_)(nil),
+		(*SystemOutput_Table_)(nil),
+		(*SystemOutput_Code_)(nil),
+		(*SystemOutput_FileTree_)(nil),
+	}
+	file_sf_codegen_conversation_v1_conversation_proto_msgTypes[14].OneofWrappers = []any{
+		(*UserInput_FormResponse_Field_TextInput)(nil),
+		(*UserInput_FormResponse_Field_Selection)(nil),
+		(*UserInput_FormResponse_Field_Confirmation)(nil),
+	}
+	file_sf_codegen_conversation_v1_conversation_proto_msgTypes[28].OneofWrappers = []any{
+		(*SystemOutput_Form_Field_TextInput)(nil),
+		(*SystemOutput_Form_Field_ListSelect)(nil),
+		(*SystemOutput_Form_Field_Confirm)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2261,7 +3507,7 @@ func file_sf_codegen_conversation_v1_conversation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sf_codegen_conversation_v1_conversation_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   21,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
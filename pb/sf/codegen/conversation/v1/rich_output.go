@@ -0,0 +1,165 @@
+package pbconvo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Text renders the table with aligned columns, for terminals and logs.
+func (t *SystemOutput_Table) Text() string {
+	widths := make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = len(column)
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row.Cells {
+			if i < len(widths) && len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	line := func(cells []string) string {
+		padded := make([]string, 0, len(widths))
+		for i, width := range widths {
+			var cell string
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			padded = append(padded, fmt.Sprintf("%-*s", width, cell))
+		}
+		return strings.TrimRight(strings.Join(padded, "  "), " ")
+	}
+
+	var lines []string
+	if t.Title != "" {
+		lines = append(lines, t.Title)
+	}
+	separators := make([]string, len(widths))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("-", width)
+	}
+	lines = append(lines, line(t.Columns), line(separators))
+	for _, row := range t.Rows {
+		lines = append(lines, line(row.Cells))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Markdown renders the table for clients that don't display tables.
+func (t *SystemOutput_Table) Markdown() string {
+	row := func(cells []string) string {
+		escaped := make([]string, len(t.Columns))
+		for i := range escaped {
+			if i < len(cells) {
+				escaped[i] = strings.ReplaceAll(cells[i], "|", `\|`)
+			}
+		}
+		return "| " + strings.Join(escaped, " | ") + " |"
+	}
+
+	var lines []string
+	if t.Title != "" {
+		lines = append(lines, "**"+t.Title+"**", "")
+	}
+	separators := make([]string, len(t.Columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	lines = append(lines, row(t.Columns), row(separators))
+	for _, r := range t.Rows {
+		lines = append(lines, row(r.Cells))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Markdown renders the snippet as a fenced code block, for clients that don't
+// display code.
+func (c *SystemOutput_Code) Markdown() string {
+	var out string
+	if c.Title != "" {
+		out = "**" + c.Title + "**\n\n"
+	}
+	return out + "```" + c.Language + "\n" + strings.TrimSuffix(c.Content, "\n") + "\n```"
+}
+
+// Text renders the files as a tree, with their sizes.
+func (f *SystemOutput_FileTree) Text() string {
+	root := &fileTreeNode{}
+	var total uint64
+	for _, file := range f.Files {
+		root.add(strings.Split(file.Path, "/"), file.Size)
+		total += file.Size
+	}
+
+	var lines []string
+	if f.Title != "" {
+		lines = append(lines, f.Title)
+	}
+	lines = root.render("", lines)
+	lines = append(lines, fmt.Sprintf("%d files, %s", len(f.Files), formatSize(total)))
+	return strings.Join(lines, "\n")
+}
+
+// Markdown renders the tree in a fenced block, for clients that don't display
+// file trees.
+func (f *SystemOutput_FileTree) Markdown() string {
+	text := f.Text()
+	var out string
+	if f.Title != "" {
+		out = "**" + f.Title + "**\n\n"
+		text = strings.TrimPrefix(text, f.Title+"\n")
+	}
+	return out + "```\n" + text + "\n```"
+}
+
+type fileTreeNode struct {
+	name     string
+	size     uint64
+	children []*fileTreeNode
+}
+
+func (n *fileTreeNode) add(parts []string, size uint64) {
+	if len(parts) == 1 {
+		n.children = append(n.children, &fileTreeNode{name: parts[0], size: size})
+		return
+	}
+	for _, child := range n.children {
+		if child.name == parts[0] && child.children != nil {
+			child.add(parts[1:], size)
+			return
+		}
+	}
+	dir := &fileTreeNode{name: parts[0], children: []*fileTreeNode{}}
+	n.children = append(n.children, dir)
+	dir.add(parts[1:], size)
+}
+
+func (n *fileTreeNode) render(indent string, lines []string) []string {
+	sort.Slice(n.children, func(i, j int) bool { return n.children[i].name < n.children[j].name })
+	for i, child := range n.children {
+		branch, nextIndent := "├── ", indent+"│   "
+		if i == len(n.children)-1 {
+			branch, nextIndent = "└── ", indent+"    "
+		}
+		if child.children != nil {
+			lines = append(lines, indent+branch+child.name+"/")
+			lines = child.render(nextIndent, lines)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s%s%s (%s)", indent, branch, child.name, formatSize(child.size)))
+	}
+	return lines
+}
+
+func formatSize(size uint64) string {
+	switch {
+	case size < 1000:
+		return fmt.Sprintf("%d B", size)
+	case size < 1000*1000:
+		return fmt.Sprintf("%.1f kB", float64(size)/1000)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1000*1000))
+	}
+}
@@ -0,0 +1,30 @@
+package codegen
+
+// Protocol versions advertised by clients in `Start.version`. Each version adds
+// prompt types that clients of a previous version cannot render, so generators
+// check ClientSupports() before using them.
+const (
+	ProtocolVersionInitial uint32 = 1
+	// ProtocolVersionUpload adds `SystemOutput.Upload` prompts, answered with `UserInput.Upload`.
+	ProtocolVersionUpload uint32 = 2
+	// ProtocolVersionForm adds `SystemOutput.Form` prompts, answered with `UserInput.FormResponse`.
+	ProtocolVersionForm uint32 = 3
+	// ProtocolVersionRichOutput adds the `SystemOutput.Table`, `Code` and `FileTree`
+	// entries. Older clients receive them as markdown messages instead.
+	ProtocolVersionRichOutput uint32 = 4
+	// ProtocolVersionMsgIDs requires `UserInput.from_msg_id` on every answer, inputs
+	// not pointing at the pending prompt are refused.
+	ProtocolVersionMsgIDs uint32 = 5
+	// ProtocolVersionReview adds the review step before generation. Older clients
+	// expect a complete state to be generated right away (ex: `substreams init
+	// --state-file`).
+	ProtocolVersionReview uint32 = 6
+
+	// ProtocolVersionLatest is the most recent version supported by the generators.
+	ProtocolVersionLatest = ProtocolVersionReview
+)
+
+// ClientSupports reports whether the client speaks at least the given protocol version.
+func (c *Conversation[X]) ClientSupports(version uint32) bool {
+	return c.factory != nil && c.factory.clientVersion >= version
+}
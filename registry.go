@@ -21,15 +21,21 @@ type ConversationHandler struct {
 	Weight int
 
 	Factory ConversationFactory
+
+	// Inputs are the input messages of the prompts asked by the conversation,
+	// besides the ones common to all generators. They make up its catalog of
+	// action IDs, see Actions().
+	Inputs []any
 }
 
-func RegisterConversation(conversationID string, title, description string, newFunc ConversationFactory, weight int) {
+func RegisterConversation(conversationID string, title, description string, newFunc ConversationFactory, weight int, inputs ...any) {
 	handler := ConversationHandler{
 		ID:          conversationID,
 		Title:       title,
 		Description: description,
 		Factory:     newFunc,
 		Weight:      weight,
+		Inputs:      inputs,
 	}
 	Registry[conversationID] = &handler
 }
@@ -0,0 +1,116 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+// EditableField is a field of the state shown in the review step before the
+// project is generated, which the user can pick to change.
+type EditableField struct {
+	// Label names the field, ex: `Contract "bayc" initial block`
+	Label string
+	// Value is the current value, as shown to the user
+	Value string
+	// Reset clears the field (and anything derived from it), so that NextStep()
+	// asks for it again.
+	Reset func()
+}
+
+type AskReview struct{}
+type InputReview struct{ pbconvo.UserInput_Selection }
+
+// ReviewGenerate is the review answer accepting the state as is.
+const ReviewGenerate = "generate"
+
+// reviewChangePrefix prefixes the index of the field to change in the review
+// answers, ex: `change_2`.
+const reviewChangePrefix = "change_"
+
+func (c *Conversation[X]) Reviewed() bool { return c.reviewed }
+
+// CmdReviewOrGenerate is the last step of NextStep(), once the state is complete:
+// clients supporting it are asked to review the state before it is generated.
+func (c *Conversation[X]) CmdReviewOrGenerate() loop.Cmd {
+	if c.ClientSupports(ProtocolVersionReview) && !c.reviewed {
+		return Cmd(AskReview{})
+	}
+	return Cmd(RunGenerate{})
+}
+
+func (c *Conversation[X]) CmdAskReview(fields []EditableField) loop.Cmd {
+	summary := []string{"Here is what I collected:", ""}
+	labels := []string{"Looks good, generate the project"}
+	values := []string{ReviewGenerate}
+	for i, field := range fields {
+		summary = append(summary, fmt.Sprintf("- **%s**: `%s`", field.Label, field.Value))
+		labels = append(labels, fmt.Sprintf("Change %s", field.Label))
+		values = append(values, reviewChangePrefix+strconv.Itoa(i))
+	}
+
+	return loop.Seq(
+		c.Msg().Message(strings.Join(summary, "\n")).Cmd(),
+		c.Action(InputReview{}).ListSelect("Do you want to change anything before generating?").
+			Labels(labels...).
+			Values(values...).
+			DefaultValue(ReviewGenerate).
+			Cmd(),
+	)
+}
+
+// ApplyReview either accepts the state, or resets the field picked by the user,
+// from the value of an InputReview.
+// The conversation continues with NextStep() in both cases, which asks for the
+// reset field again and comes back to the review afterwards.
+func (c *Conversation[X]) ApplyReview(value string, fields []EditableField) {
+	if value == ReviewGenerate {
+		c.reviewed = true
+		return
+	}
+
+	c.reviewed = false
+	idx, err := strconv.Atoi(strings.TrimPrefix(value, reviewChangePrefix))
+	if err != nil || idx < 0 || idx >= len(fields) {
+		return
+	}
+	fields[idx].Reset()
+}
+
+// ProjectNameField is the project name, asked again through AskProjectName once reset.
+func ProjectNameField(name *string) EditableField {
+	return EditableField{
+		Label: "Project name",
+		Value: *name,
+		Reset: func() { *name = "" },
+	}
+}
+
+// ChainNameField is the chain, asked again through AskChainName once reset.
+// displayName falls back to the chain ID when empty.
+func ChainNameField(chainName *string, displayName string) EditableField {
+	if displayName == "" {
+		displayName = *chainName
+	}
+	return EditableField{
+		Label: "Chain",
+		Value: displayName,
+		Reset: func() { *chainName = "" },
+	}
+}
+
+// InitialBlockField is the start block, asked again through AskInitialStartBlockType
+// once reset.
+func InitialBlockField(initialBlock *uint64, initialBlockSet *bool) EditableField {
+	return EditableField{
+		Label: "Initial block",
+		Value: strconv.FormatUint(*initialBlock, 10),
+		Reset: func() {
+			*initialBlock = 0
+			*initialBlockSet = false
+		},
+	}
+}
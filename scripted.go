@@ -0,0 +1,402 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// Answers drives a conversation without any user: every prompt is answered with
+// the values found under its key, which is either the prompt's action ID, with
+// or without its generator ID (ex: `evm-events-calls.contract_address` or
+// `contract_address`), or the type of the input message it expects (ex:
+// `InputContractAddress`, or fully qualified as `evm_events_calls.InputContractAddress`).
+// Action IDs are preferred, they are the ones listed by `Discover`.
+//
+// A list of values answers the same prompt successive times, in order. Confirm
+// prompts take `yes`/`no` or `true`/`false`, multi-select lists take the
+// comma-separated selected values (all the default ones when not answered),
+// uploads take the path of the file to upload, read like `file://` paths, and
+// forms take a map of the values of their fields by name (the default ones when
+// not answered).
+type Answers struct {
+	Generator string `yaml:"generator"`
+	// ProtocolVersion is the version of the protocol the conversation is run
+	// with, ProtocolVersionInitial when not set. Uploads and forms are only asked
+	// from ProtocolVersionUpload and ProtocolVersionForm on.
+	ProtocolVersion uint32                  `yaml:"protocol_version"`
+	Answers         map[string]AnswerValues `yaml:"answers"`
+
+	// LocalFilesRoot is the directory from which `file://` paths found in the
+	// answers can be read, see MsgWrapFactory.SetLocalFilesRoot. Set by the caller,
+	// it is never read from the answers file.
+	LocalFilesRoot string `yaml:"-"`
+
+	consumed map[string]int
+}
+
+// AnswerValues is either a single answer or a list of answers in the answers file.
+type AnswerValues []AnswerValue
+
+func (v *AnswerValues) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		var answer AnswerValue
+		if err := value.Decode(&answer); err != nil {
+			return err
+		}
+		*v = AnswerValues{answer}
+		return nil
+	}
+
+	var values []AnswerValue
+	if err := value.Decode(&values); err != nil {
+		return err
+	}
+	*v = values
+	return nil
+}
+
+// AnswerValue is an answer of the answers file: a value, or the values of the
+// fields of a form, by name.
+type AnswerValue struct {
+	Value  string
+	Fields map[string]string
+}
+
+func (v *AnswerValue) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.MappingNode {
+		return value.Decode(&v.Fields)
+	}
+	return value.Decode(&v.Value)
+}
+
+// LoadAnswers reads an answers file, in YAML or JSON format.
+func LoadAnswers(path string) (*Answers, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading answers file: %w", err)
+	}
+
+	answers := &Answers{}
+	if err := yaml.Unmarshal(cnt, answers); err != nil {
+		return nil, fmt.Errorf("decoding answers file %q: %w", path, err)
+	}
+	return answers, nil
+}
+
+// next returns the next unused answer for the first of the keys found in the answers.
+func (a *Answers) next(keys ...string) (AnswerValue, bool) {
+	if a.consumed == nil {
+		a.consumed = make(map[string]int)
+	}
+
+	for _, key := range keys {
+		values, found := a.Answers[key]
+		if !found {
+			continue
+		}
+		idx := a.consumed[key]
+		if idx >= len(values) {
+			return AnswerValue{}, false
+		}
+		a.consumed[key] = idx + 1
+		return values[idx], true
+	}
+	return AnswerValue{}, false
+}
+
+// UnansweredPromptError is returned when a scripted conversation reaches a prompt
+// for which the answers file has no (more) values.
+type UnansweredPromptError struct {
+	Keys   []string
+	Prompt string
+}
+
+func (e *UnansweredPromptError) Error() string {
+	return fmt.Sprintf("no answer for prompt %q, expected one of the keys: %s", e.Prompt, strings.Join(e.Keys, ", "))
+}
+
+// RunScripted runs a full conversation of the answers' generator, answering every
+// prompt from the answers file. The conversation runs in the event loop of the
+// server, going through the regular `Update()` path with its validations,
+// explorer lookups, command timeouts and traces; only the transport is replaced
+// by the answers.
+//
+// `onEvent`, if not nil, receives a human-readable line for every output and answer.
+func RunScripted(ctx context.Context, answers *Answers, onEvent func(event string)) (map[string][]byte, error) {
+	handler := Registry[answers.Generator]
+	if handler == nil {
+		return nil, fmt.Errorf("no conversation handler found for generator ID %q", answers.Generator)
+	}
+	version := answers.ProtocolVersion
+	if version == 0 {
+		version = ProtocolVersionInitial
+	}
+
+	factory := NewMsgWrapFactory(nil)
+	factory.SetGeneratorID(handler.ID)
+	factory.SetClientVersion(version)
+	factory.SetLocalFilesRoot(answers.LocalFilesRoot)
+	conversation := handler.Factory()
+	conversation.SetFactory(factory)
+
+	begin := time.Now()
+	event := func(humanize func(seconds int) string) {
+		if onEvent != nil {
+			onEvent(humanize(int(time.Since(begin).Seconds())))
+		}
+	}
+
+	var projectFiles map[string][]byte
+	factory.SetupLoop(func(msg loop.Msg) loop.Cmd {
+		switch msg := msg.(type) {
+		case *pbconvo.SystemOutput:
+			event(msg.Humanize)
+			if download := msg.GetDownloadFiles(); download != nil {
+				projectFiles = make(map[string][]byte, len(download.Files))
+				for _, file := range download.Files {
+					projectFiles[file.Filename] = file.Content
+				}
+				return nil
+			}
+			if !msg.IsPrompt() {
+				return nil
+			}
+			incoming, err := answerScripted(answers, factory, msg)
+			if err != nil {
+				return loop.Quit(err)
+			}
+			return func() loop.Msg { return incoming }
+		case IncomingMessage:
+			event(msg.Humanize)
+			return CmdIncoming(conversation, factory, msg)
+		case loop.PanicMsg:
+			// The loop quits with the panic.
+			return nil
+		}
+		return TracedUpdate(ctx, conversation, handler.ID, msg)
+	})
+
+	start := &pbconvo.UserInput_Start{GeneratorId: handler.ID, Version: version}
+	msg := NewMsgStart(start, false)
+	event(msg.Humanize)
+	if err := factory.Run(ctx, func() loop.Msg { return NewMsgStart(start, false) }); err != nil {
+		return nil, err
+	}
+
+	if projectFiles == nil {
+		return nil, fmt.Errorf("conversation ended without generating any files")
+	}
+	return projectFiles, nil
+}
+
+// answerScripted answers the prompt from the answers file, like a client would.
+func answerScripted(answers *Answers, factory *MsgWrapFactory, prompt *pbconvo.SystemOutput) (IncomingMessage, error) {
+	req, err := answerPrompt(answers, factory.LastInput(), prompt)
+	if err != nil {
+		return IncomingMessage{}, err
+	}
+	// answers files cannot correct an answer, unlike users
+	if reason, _ := checkInput(prompt, req); reason != "" {
+		return IncomingMessage{}, fmt.Errorf("invalid answer to %q: %s", promptText(prompt), reason)
+	}
+	return factory.DecodeInput(req)
+}
+
+func answerPrompt(answers *Answers, inputType reflect.Type, prompt *pbconvo.SystemOutput) (*pbconvo.UserInput, error) {
+	var keys []string
+	if prompt.ActionId != "" {
+		keys = append(keys, prompt.ActionId, shortActionID(prompt.ActionId))
+	}
+	if inputType != nil {
+		keys = append(keys, inputType.String(), inputType.Name())
+	}
+
+	answer, found := answers.next(keys...)
+	if !found && inputType == reflect.TypeOf(InputReview{}) {
+		// answers files don't have to review the collected state
+		answer, found = AnswerValue{Value: ReviewGenerate}, true
+	}
+	if sel := prompt.GetListSelect(); !found && sel != nil && sel.SelectMany && len(sel.DefaultValues) != 0 {
+		// nor pick among entries that are all selected by default
+		answer, found = AnswerValue{Value: strings.Join(sel.DefaultValues, ",")}, true
+	}
+	if !found && prompt.GetForm() != nil {
+		// nor fill forms whose fields all have defaults
+		answer, found = AnswerValue{Fields: map[string]string{}}, true
+	}
+	if !found {
+		return nil, &UnansweredPromptError{Keys: keys, Prompt: promptText(prompt)}
+	}
+
+	switch entry := prompt.Entry.(type) {
+	case *pbconvo.SystemOutput_Upload_:
+		return uploadFromPath(answers.LocalFilesRoot, answer.Value)
+	case *pbconvo.SystemOutput_Form_:
+		if answer.Fields == nil {
+			return nil, fmt.Errorf("answer to %q: expected the values of the form fields by name, got %q", entry.Form.Prompt, answer.Value)
+		}
+		return formFromValues(entry.Form, answer.Fields)
+	}
+	if answer.Fields != nil {
+		return nil, fmt.Errorf("answer to %q: expected a value, got form fields", promptText(prompt))
+	}
+	return inputFromValue(prompt, answer.Value)
+}
+
+// uploadFromPath uploads the file at path, which must resolve inside the local
+// files root like `file://` paths.
+func uploadFromPath(root string, path string) (*pbconvo.UserInput, error) {
+	if root == "" {
+		return nil, ErrLocalFilesDisabled
+	}
+	resolved, err := resolveInRoot(root, path)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("reading file to upload: %w", err)
+	}
+	return &pbconvo.UserInput{Entry: &pbconvo.UserInput_File{File: &pbconvo.UserInput_Upload{
+		Filename: filepath.Base(resolved),
+		Content:  content,
+	}}}, nil
+}
+
+// formFromValues answers the form with the values of its fields, written as in
+// the answers files. Fields without a value take their default one.
+func formFromValues(form *pbconvo.SystemOutput_Form, values map[string]string) (*pbconvo.UserInput, error) {
+	response := &pbconvo.UserInput_FormResponse{}
+	for _, field := range form.Fields {
+		value, found := values[field.Name]
+		if !found {
+			value, found = formFieldDefault(field)
+		}
+		if !found {
+			return nil, fmt.Errorf("answer to %q: no value for the field %q", form.Prompt, field.Name)
+		}
+		input, err := inputFromValue(field.AsPrompt(), value)
+		if err != nil {
+			return nil, fmt.Errorf("answer to %q: field %q: %w", form.Prompt, field.Name, err)
+		}
+		response.Fields = append(response.Fields, formResponseField(field.Name, input))
+	}
+	for name := range values {
+		if !slices.ContainsFunc(form.Fields, func(field *pbconvo.SystemOutput_Form_Field) bool { return field.Name == name }) {
+			return nil, fmt.Errorf("answer to %q: no field %q in the form", form.Prompt, name)
+		}
+	}
+	return &pbconvo.UserInput{Entry: &pbconvo.UserInput_FormResponse_{FormResponse: response}}, nil
+}
+
+// formFieldDefault returns the value the field is pre-filled with, written as in
+// the answers files, if any.
+func formFieldDefault(field *pbconvo.SystemOutput_Form_Field) (string, bool) {
+	switch entry := field.Entry.(type) {
+	case *pbconvo.SystemOutput_Form_Field_TextInput:
+		return entry.TextInput.DefaultValue, entry.TextInput.DefaultValue != ""
+	case *pbconvo.SystemOutput_Form_Field_ListSelect:
+		if entry.ListSelect.SelectMany {
+			return strings.Join(entry.ListSelect.DefaultValues, ","), len(entry.ListSelect.DefaultValues) != 0
+		}
+		return entry.ListSelect.DefaultValue, entry.ListSelect.DefaultValue != ""
+	case *pbconvo.SystemOutput_Form_Field_Confirm:
+		switch entry.Confirm.DefaultButton {
+		case pbconvo.SystemOutput_Confirm_CONFIRM:
+			return "yes", true
+		case pbconvo.SystemOutput_Confirm_DECLINE:
+			return "no", true
+		}
+	}
+	return "", false
+}
+
+// formResponseField returns the answer to a standalone prompt as the answer to
+// the form field of the same type.
+func formResponseField(name string, input *pbconvo.UserInput) *pbconvo.UserInput_FormResponse_Field {
+	field := &pbconvo.UserInput_FormResponse_Field{Name: name}
+	switch entry := input.Entry.(type) {
+	case *pbconvo.UserInput_TextInput_:
+		field.Entry = &pbconvo.UserInput_FormResponse_Field_TextInput{TextInput: entry.TextInput}
+	case *pbconvo.UserInput_Selection_:
+		field.Entry = &pbconvo.UserInput_FormResponse_Field_Selection{Selection: entry.Selection}
+	case *pbconvo.UserInput_Confirmation_:
+		field.Entry = &pbconvo.UserInput_FormResponse_Field_Confirmation{Confirmation: entry.Confirmation}
+	}
+	return field
+}
+
+// inputFromValue converts a value, written as in the answers files, into the
+// UserInput answering the prompt.
+func inputFromValue(prompt *pbconvo.SystemOutput, value string) (*pbconvo.UserInput, error) {
+	switch entry := prompt.Entry.(type) {
+	case *pbconvo.SystemOutput_TextInput_:
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_TextInput_{
+			TextInput: &pbconvo.UserInput_TextInput{Value: value},
+		}}, nil
+
+	case *pbconvo.SystemOutput_ListSelect_:
+		if entry.ListSelect.SelectMany {
+			selection := &pbconvo.UserInput_Selection{}
+			for _, v := range strings.Split(value, ",") {
+				v = strings.TrimSpace(v)
+				if v == "" {
+					continue
+				}
+				selection.Values = append(selection.Values, v)
+				selection.Labels = append(selection.Labels, listSelectLabel(entry.ListSelect, v))
+			}
+			return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Selection_{Selection: selection}}, nil
+		}
+
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Selection_{
+			Selection: &pbconvo.UserInput_Selection{Label: listSelectLabel(entry.ListSelect, value), Value: value},
+		}}, nil
+
+	case *pbconvo.SystemOutput_Confirm_:
+		affirmative, err := parseAffirmative(value)
+		if err != nil {
+			return nil, fmt.Errorf("answer to %q: %w", entry.Confirm.Prompt, err)
+		}
+		return &pbconvo.UserInput{Entry: &pbconvo.UserInput_Confirmation_{
+			Confirmation: &pbconvo.UserInput_Confirmation{Affirmative: affirmative},
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported prompt entry type %T", prompt.Entry)
+}
+
+func listSelectLabel(sel *pbconvo.SystemOutput_ListSelect, value string) string {
+	for i, v := range sel.Values {
+		if v == value && i < len(sel.Labels) {
+			return sel.Labels[i]
+		}
+	}
+	return value
+}
+
+func parseAffirmative(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y":
+		return true, nil
+	case "no", "n":
+		return false, nil
+	}
+
+	affirmative, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid confirmation %q, expected yes/no or true/false", value)
+	}
+	return affirmative, nil
+}
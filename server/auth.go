@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	codegen "github.com/streamingfast/substreams-codegen"
+)
+
+// Credentials are what a client presents to authenticate: an API key in the
+// `X-Api-Key` header, or a bearer token (ex: a JWT) in the `Authorization` one.
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// Verifier authenticates the clients of the server.
+type Verifier interface {
+	// Verify returns the identity of the credentials, or an error if they are
+	// not valid.
+	Verify(ctx context.Context, credentials Credentials) (*codegen.Identity, error)
+}
+
+var ErrMissingCredentials = errors.New("authentication required, pass an API key in the X-Api-Key header or a bearer token in the Authorization header")
+
+func credentialsOf(header http.Header) Credentials {
+	credentials := Credentials{APIKey: header.Get("X-Api-Key")}
+	if token, found := strings.CutPrefix(header.Get("Authorization"), "Bearer "); found {
+		credentials.BearerToken = strings.TrimSpace(token)
+	}
+	return credentials
+}
+
+// authInterceptor authenticates every request with the verifier, passing the
+// identity of the client along in the context of the handlers.
+type authInterceptor struct {
+	verifier Verifier
+}
+
+func (a *authInterceptor) authenticate(ctx context.Context, header http.Header) (context.Context, error) {
+	credentials := credentialsOf(header)
+	if credentials == (Credentials{}) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, ErrMissingCredentials)
+	}
+	identity, err := a.verifier.Verify(ctx, credentials)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	return codegen.WithIdentity(ctx, identity), nil
+}
+
+func (a *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := a.authenticate(ctx, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (a *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (a *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := a.authenticate(ctx, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
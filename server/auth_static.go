@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"gopkg.in/yaml.v3"
+)
+
+// StaticVerifier authenticates the clients from a file, in YAML or JSON format,
+// listing the valid API keys and the secret of the JWT bearer tokens. It is
+// meant for testing and small deployments:
+//
+//	api_keys:
+//	  some-secret-key:
+//	    subject: alice
+//	    tenant: partner-a
+//	    generators: [evm-events-calls, evm-minimal]
+//	jwt_secret: another-secret
+//	tenants:
+//	  partner-a:
+//	    env:
+//	      CODEGEN_MAINNET_API_KEY: partner-a-etherscan-key
+//
+// The JWTs must be signed with HS256, their `sub`, `tenant` and `generators`
+// claims make the identity, and their `exp` and `nbf` claims are checked if
+// present. The `env` of a tenant overrides the environment variables of the
+// server for the conversations of its identities, see codegen.Getenv.
+type StaticVerifier struct {
+	APIKeys   map[string]StaticIdentity `yaml:"api_keys"`
+	JWTSecret string                    `yaml:"jwt_secret"`
+	Tenants   map[string]StaticTenant   `yaml:"tenants"`
+}
+
+type StaticIdentity struct {
+	Subject    string   `yaml:"subject"`
+	Tenant     string   `yaml:"tenant"`
+	Generators []string `yaml:"generators"`
+}
+
+type StaticTenant struct {
+	Env map[string]string `yaml:"env"`
+}
+
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// NewStaticVerifier reads a StaticVerifier file.
+func NewStaticVerifier(path string) (*StaticVerifier, error) {
+	cnt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading auth file: %w", err)
+	}
+
+	verifier := &StaticVerifier{}
+	if err := yaml.Unmarshal(cnt, verifier); err != nil {
+		return nil, fmt.Errorf("decoding auth file %q: %w", path, err)
+	}
+	for _, identity := range verifier.APIKeys {
+		if identity.Subject == "" {
+			return nil, fmt.Errorf("decoding auth file %q: every API key must have a subject", path)
+		}
+	}
+	return verifier, nil
+}
+
+func (v *StaticVerifier) Verify(ctx context.Context, credentials Credentials) (*codegen.Identity, error) {
+	var identity StaticIdentity
+	switch {
+	case credentials.APIKey != "":
+		found := false
+		for key, candidate := range v.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(credentials.APIKey)) == 1 {
+				identity, found = candidate, true
+			}
+		}
+		if !found {
+			return nil, ErrInvalidCredentials
+		}
+	case credentials.BearerToken != "" && v.JWTSecret != "":
+		claims, err := verifyHS256(credentials.BearerToken, []byte(v.JWTSecret), time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+		}
+		identity = StaticIdentity{Subject: claims.Subject, Tenant: claims.Tenant, Generators: claims.Generators}
+	default:
+		return nil, ErrInvalidCredentials
+	}
+
+	return &codegen.Identity{
+		Subject:    identity.Subject,
+		Tenant:     identity.Tenant,
+		Generators: identity.Generators,
+		Env:        v.Tenants[identity.Tenant].Env,
+	}, nil
+}
+
+type jwtClaims struct {
+	Subject    string   `json:"sub"`
+	Tenant     string   `json:"tenant"`
+	Generators []string `json:"generators"`
+	ExpiresAt  int64    `json:"exp"`
+	NotBefore  int64    `json:"nbf"`
+}
+
+// verifyHS256 checks the signature and validity period of a JWT signed with
+// HS256, returning its claims.
+func verifyHS256(token string, secret []byte, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+	if header.Algorithm != "HS256" {
+		return nil, fmt.Errorf("unsupported algorithm %q, only HS256 is", header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid signature")
+	}
+
+	claims := &jwtClaims{}
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return nil, fmt.Errorf("decoding claims: %w", err)
+	}
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, errors.New("token expired")
+	}
+	if claims.NotBefore != 0 && now.Unix() < claims.NotBefore {
+		return nil, errors.New("token not valid yet")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+	return claims, nil
+}
+
+func decodeJWTPart(part string, v any) error {
+	cnt, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(cnt, v)
+}
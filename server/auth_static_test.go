@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticVerifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+api_keys:
+  alice-key:
+    subject: alice
+    tenant: partner-a
+    generators: [evm-minimal]
+jwt_secret: jwt-secret
+tenants:
+  partner-a:
+    env:
+      CODEGEN_MAINNET_API_KEY: partner-a-key
+`), 0644))
+	verifier, err := NewStaticVerifier(path)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	identity, err := verifier.Verify(ctx, Credentials{APIKey: "alice-key"})
+	require.NoError(t, err)
+	assert.Equal(t, &codegen.Identity{
+		Subject:    "alice",
+		Tenant:     "partner-a",
+		Generators: []string{"evm-minimal"},
+		Env:        map[string]string{"CODEGEN_MAINNET_API_KEY": "partner-a-key"},
+	}, identity)
+	assert.True(t, identity.Allows("evm-minimal"))
+	assert.False(t, identity.Allows("evm-events-calls"))
+	assert.Equal(t, "partner-a-key", codegen.Getenv(codegen.WithIdentity(ctx, identity), "CODEGEN_MAINNET_API_KEY"))
+
+	_, err = verifier.Verify(ctx, Credentials{APIKey: "bob-key"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	now := time.Now().Unix()
+	identity, err = verifier.Verify(ctx, Credentials{BearerToken: signHS256(t, `{"alg":"HS256","typ":"JWT"}`, `{"sub":"bob","tenant":"partner-a","exp":`+itoa(now+60)+`}`, "jwt-secret")})
+	require.NoError(t, err)
+	assert.Equal(t, "bob", identity.Subject)
+	assert.Equal(t, "partner-a-key", identity.Env["CODEGEN_MAINNET_API_KEY"])
+	assert.True(t, identity.Allows("evm-events-calls"))
+
+	for name, token := range map[string]string{
+		"expired":    signHS256(t, `{"alg":"HS256"}`, `{"sub":"bob","exp":`+itoa(now-60)+`}`, "jwt-secret"),
+		"not yet":    signHS256(t, `{"alg":"HS256"}`, `{"sub":"bob","nbf":`+itoa(now+60)+`}`, "jwt-secret"),
+		"other key":  signHS256(t, `{"alg":"HS256"}`, `{"sub":"bob"}`, "other-secret"),
+		"alg none":   signHS256(t, `{"alg":"none"}`, `{"sub":"bob"}`, "jwt-secret"),
+		"no subject": signHS256(t, `{"alg":"HS256"}`, `{"tenant":"partner-a"}`, "jwt-secret"),
+		"malformed":  "not-a-jwt",
+		"unsigned":   b64(`{"alg":"HS256"}`) + "." + b64(`{"sub":"bob"}`) + ".",
+	} {
+		_, err := verifier.Verify(ctx, Credentials{BearerToken: token})
+		assert.ErrorIs(t, err, ErrInvalidCredentials, name)
+	}
+}
+
+func TestCredentialsOf(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Api-Key", "alice-key")
+	header.Set("Authorization", "Bearer some.jwt.token")
+	assert.Equal(t, Credentials{APIKey: "alice-key", BearerToken: "some.jwt.token"}, credentialsOf(header))
+
+	header = http.Header{}
+	header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	assert.Equal(t, Credentials{}, credentialsOf(header))
+}
+
+func signHS256(t *testing.T, header, claims, secret string) string {
+	t.Helper()
+	signed := b64(header) + "." + b64(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func b64(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+func itoa(i int64) string { return strconv.FormatInt(i, 10) }
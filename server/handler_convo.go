@@ -7,8 +7,8 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	connect "connectrpc.com/connect"
@@ -18,8 +18,6 @@ import (
 	"github.com/tidwall/sjson"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
-	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/reflect/protoreflect"
 
 	_ "github.com/streamingfast/substreams-codegen/evm-events-calls"
 	_ "github.com/streamingfast/substreams-codegen/evm-minimal"
@@ -34,12 +32,17 @@ import (
 )
 
 func (s *server) Discover(ctx context.Context, req *connect.Request[pbconvo.DiscoveryRequest]) (*connect.Response[pbconvo.DiscoveryResponse], error) {
+	identity := codegen.IdentityFromContext(ctx)
 	var generators []*pbconvo.DiscoveryResponse_Generator
 	for _, conv := range codegen.ListConversationHandlers() {
+		if identity != nil && !identity.Allows(conv.ID) {
+			continue
+		}
 		generators = append(generators, &pbconvo.DiscoveryResponse_Generator{
 			Id:          conv.ID,
 			Title:       conv.Title,
 			Description: conv.Description,
+			Actions:     conv.Actions(),
 		})
 
 	}
@@ -48,15 +51,30 @@ func (s *server) Discover(ctx context.Context, req *connect.Request[pbconvo.Disc
 	}), nil
 }
 
-type eventLogger struct {
-	loggedEvents []string
-}
+// observe updates the metrics with a new record of the session, and prints it
+// as text when debugging events.
+func observe(session *codegen.Session, record *codegen.SessionRecord) {
+	switch {
+	case record.Input.GetStart() != nil:
+		codegen.ConversationsStarted.Inc(session.Generator)
+		codegen.ActiveConversations.Inc(session.Generator)
+	case record.Direction == codegen.SessionIn && record.ActionID != "":
+		codegen.PromptDuration.WithLabelValues(session.Generator, record.ActionID).Observe(float64(record.DurationMs) / 1000)
+	case record.Kind == codegen.SessionKindEnd:
+		codegen.ActiveConversations.Dec(session.Generator)
+		codegen.ConversationsEnded.Inc(session.Generator, record.Outcome)
+	}
 
-func (e *eventLogger) logEvent(event string) {
 	if os.Getenv("SUBSTREAMS_DEV_DEBUG_EVENTS") == "true" {
-		fmt.Println(event)
+		fmt.Println(session.Render(record))
 	}
-	e.loggedEvents = append(e.loggedEvents, event)
+}
+
+// limitMessage tells the user about the limit their conversation reached.
+func limitMessage(limitErr *limitError) *pbconvo.SystemOutput {
+	return &pbconvo.SystemOutput{Entry: &pbconvo.SystemOutput_Message_{
+		Message: &pbconvo.SystemOutput_Message{Markdown: limitErr.message, Style: "error"},
+	}}
 }
 
 func (s *server) Converse(ctx context.Context, stream *connect.BidiStream[pbconvo.UserInput, pbconvo.SystemOutput]) (err error) {
@@ -67,7 +85,21 @@ func (s *server) Converse(ctx context.Context, stream *connect.BidiStream[pbconv
 		}
 	}()
 
-	s.logger.Info("new conversation")
+	identity := codegen.IdentityFromContext(ctx)
+	client := s.limiter.clientOf(identity, stream.Peer().Addr, stream.RequestHeader())
+	release, err := s.limiter.acquire(client)
+	if err != nil {
+		var limitErr *limitError
+		if errors.As(err, &limitErr) {
+			s.logger.Info("conversation refused", zap.String("client", client), zap.Error(err))
+			codegen.LimitedConversations.Inc(limitErr.limit)
+			stream.Send(limitMessage(limitErr))
+		}
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	}
+	defer release()
+
+	s.logger.Info("new conversation", zap.String("client", client))
 	closeOnce := sync.Once{}
 	sendFunc := func(msg *pbconvo.SystemOutput, err error) {
 		if msg == nil {
@@ -97,16 +129,41 @@ func (s *server) Converse(ctx context.Context, stream *connect.BidiStream[pbconv
 	if convo == nil {
 		return fmt.Errorf("no conversation handler found for topic ID %q", start.Start.GeneratorId)
 	}
+	if identity != nil && !identity.Allows(convo.ID) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s is not allowed to use generator %q", identity.Subject, convo.ID))
+	}
 
-	evts := &eventLogger{}
-	begin := time.Now()
-	s.logger.Info("launching thread")
-	evts.logEvent(fmt.Sprintf("   0┃ [Start, hydrate: %t] %s", start.Start.Hydrate != nil, start.Start.GeneratorId))
+	unsigned, err := s.checkHydrate(start.Start.Hydrate)
+	if err != nil {
+		return err
+	}
+
+	reconnecting := codegen.IsReconnect(start.Start.Hydrate)
+	seq := newMsgSequence(0, start.Start.Version)
+	if reconnecting {
+		seq = newMsgSequence(start.Start.Hydrate.LastMsgId, start.Start.Version)
+	}
+
+	session := codegen.NewSession(convo.ID)
+	session.Identity = identity
+	launchFields := []zap.Field{zap.String("session_id", session.ID)}
+	if identity != nil {
+		launchFields = append(launchFields, zap.String("subject", identity.Subject), zap.String("tenant", identity.Tenant))
+	}
+	s.logger.Info("launching thread", launchFields...)
+	observe(session, session.Start(req, unsigned, reconnecting))
 
 	msgWrapFactory := codegen.NewMsgWrapFactory(sendFunc)
+	msgWrapFactory.SetStateSigner(s.stateSigner)
+	msgWrapFactory.SetGeneratorID(convo.ID)
+	msgWrapFactory.SetClientVersion(start.Start.Version)
+	msgWrapFactory.SetLocalFilesRoot(s.localFilesRoot)
+	msgWrapFactory.SetCommandTimeout(s.commandTimeout)
+	msgWrapFactory.SetIdentity(identity)
 	conversation := convo.Factory()
 	conversation.SetFactory(msgWrapFactory)
 
+	var streamClosed atomic.Bool
 	readNextCmd := func() loop.Msg {
 		select {
 		case <-ctx.Done():
@@ -116,98 +173,47 @@ func (s *server) Converse(ctx context.Context, stream *connect.BidiStream[pbconv
 
 		req, err := stream.Receive()
 		if err != nil {
+			streamClosed.Store(true)
 			return loop.NewQuitMsg(err)
 		}
 
-		reflectType := msgWrapFactory.LastInput()
-		if reflectType == nil {
-			// TODO: make this a "BadRequest" or InvalidRequest error, shown to the user
-			return loop.NewQuitMsg(fmt.Errorf("message type %q was not registered or does not exist", req.FromActionId))
+		if err := seq.checkInput(req); err != nil {
+			return loop.NewQuitMsg(err)
 		}
-		newMsg := reflect.New(reflectType)
-		newProtoMsg := newMsg.Interface().(protoreflect.ProtoMessage)
-
-		switch entry := req.Entry.(type) {
-		case *pbconvo.UserInput_Confirmation_:
-			cnt, err := proto.Marshal(entry.Confirmation)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("marshal type %T: %w", entry.Confirmation, err))
-			}
-			err = proto.Unmarshal(cnt, newProtoMsg)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("unmarshal into type %T from %T: %w", newProtoMsg, entry.Confirmation, err))
-			}
-			return codegen.IncomingMessage{Msg: newMsg.Elem().Interface()}
 
-		case *pbconvo.UserInput_Selection_:
-			cnt, err := proto.Marshal(entry.Selection)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("marshal type %T: %w", entry.Selection, err))
-			}
-			err = proto.Unmarshal(cnt, newProtoMsg)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("unmarshal into type %T from %T: %w", newProtoMsg, entry.Selection, err))
-			}
-			return codegen.IncomingMessage{Msg: newMsg.Elem().Interface()}
-
-		case *pbconvo.UserInput_TextInput_:
-			cnt, err := proto.Marshal(entry.TextInput)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("marshal type %T: %w", entry.TextInput, err))
-			}
-			err = proto.Unmarshal(cnt, newProtoMsg)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("unmarshal into type %T from %T: %w", newProtoMsg, entry.TextInput, err))
-			}
-			return codegen.IncomingMessage{Msg: newMsg.Elem().Interface()}
-
-		case *pbconvo.UserInput_DownloadedFiles_:
-			cnt, err := proto.Marshal(entry.DownloadedFiles)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("marshal type %T: %w", entry.DownloadedFiles, err))
-			}
-			err = proto.Unmarshal(cnt, newProtoMsg)
-			if err != nil {
-				return loop.NewQuitMsg(fmt.Errorf("unmarshal into type %T from %T: %w", newProtoMsg, entry.DownloadedFiles, err))
-			}
-			return codegen.IncomingMessage{Msg: newMsg.Elem().Interface()}
-
-		case *pbconvo.UserInput_File:
-			return loop.NewQuitMsg(fmt.Errorf("file upload not supported here"))
-
-		default:
-			return loop.NewQuitMsg(fmt.Errorf("unknown entry type %T", entry))
+		msg, err := msgWrapFactory.DecodeInput(req)
+		if err != nil {
+			return loop.NewQuitMsg(err)
 		}
+		return msg
 	}
 
 	initCmd := loop.Batch(
 		func() loop.Msg {
-			return codegen.MsgStart{UserInput_Start: *start.Start}
+			return codegen.NewMsgStart(start.Start, unsigned)
 		},
 		readNextCmd,
 	)
 
-	var lastMessageIsIncoming bool
-	var lastState string
 	msgWrapFactory.SetupLoop(func(msg loop.Msg) loop.Cmd {
 		asJSON, _ := json.Marshal(msg)
 		asJSON, _ = sjson.DeleteBytes(asJSON, "state")
 		s.logger.Debug("main Loop", zap.Any("loop_msg_type", msg), zap.String("content", string(asJSON)))
 		switch msg := msg.(type) {
 		case *pbconvo.SystemOutput:
-			ev := msg.Humanize(int(time.Since(begin).Seconds()))
-			if lastMessageIsIncoming {
-				ev = "\n" + ev
-				lastMessageIsIncoming = false
-			}
-			evts.logEvent(ev)
-			lastState = msg.State
+			seq.stamp(msg)
+			observe(session, session.AddOutput(msg))
 			sendFunc(msg, nil)
 			return nil
 		case codegen.IncomingMessage:
-			lastMessageIsIncoming = true
-			evts.logEvent("\n" + msg.Humanize(int(time.Since(begin).Seconds())))
-			return loop.Batch(func() loop.Msg { return msg.Msg }, readNextCmd)
+			observe(session, session.AddInput(msg))
+			return loop.Batch(codegen.CmdIncoming(conversation, msgWrapFactory, msg), readNextCmd)
+		case loop.PanicMsg:
+			// The loop quits with the panic once the user is told about it.
+			return msgWrapFactory.NewMsg(conversation.GetState()).
+				Message("Sorry, an internal error occurred and the conversation cannot continue. It was saved for us to investigate.").
+				Style("error").
+				Cmd()
 		}
 
 		s.logger.Debug("updating")
@@ -215,9 +221,21 @@ func (s *server) Converse(ctx context.Context, stream *connect.BidiStream[pbconv
 			fmt.Printf("convo Update message: %T %#v\n-> state: %#v\n\n", msg, msg, conversation.GetState())
 		}
 
-		cmd := conversation.Update(msg)
-		return cmd
+		return codegen.TracedUpdate(ctx, conversation, convo.ID, msg)
 	})
+	msgWrapFactory.SetCommandObserver(func(result loop.Msg, elapsed time.Duration) {
+		observe(session, session.AddCommand(result, elapsed))
+	})
+
+	if max := s.limits.LookupsPerConversation; max > 0 {
+		ctx = codegen.WithLookupLimit(ctx, max, func() {
+			limitErr := lookupLimitError(max)
+			s.logger.Info("conversation closed", zap.String("client", client), zap.String("session_id", session.ID), zap.Error(limitErr))
+			codegen.LimitedConversations.Inc(limitErr.limit)
+			msgWrapFactory.Send(limitMessage(limitErr))
+			msgWrapFactory.Send(loop.NewQuitMsg(connect.NewError(connect.CodeResourceExhausted, limitErr)))
+		})
+	}
 
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -234,14 +252,19 @@ func (s *server) Converse(ctx context.Context, stream *connect.BidiStream[pbconv
 
 		err = msgWrapFactory.Run(ctx, initCmd)
 		if err != nil {
-			evts.logEvent(fmt.Sprintf("ERROR %q AFTER %d seconds", err.Error(), int(time.Since(begin).Seconds())))
-			s.sessionLogger.SaveSession(start.Start.GeneratorId, evts.loggedEvents, lastState)
-			s.logger.Warn("failed to save session", zap.Error(err))
+			var panicErr *loop.PanicError
+			if errors.As(err, &panicErr) {
+				s.logger.Error("conversation panicked", zap.Any("panic", panicErr.Value), zap.ByteString("stack", panicErr.Stack))
+			}
+			observe(session, session.End(err, ctx.Err() != nil || streamClosed.Load()))
+			if err := s.sessionLogger.SaveSession(session); err != nil {
+				s.logger.Warn("failed to save session", zap.Error(err))
+			}
 			return err
 		}
-		evts.logEvent(fmt.Sprintf("COMPLETED IN %d seconds", int(time.Since(begin).Seconds())))
+		observe(session, session.End(nil, false))
 
-		if err := s.sessionLogger.SaveSession(start.Start.GeneratorId, evts.loggedEvents, lastState); err != nil {
+		if err := s.sessionLogger.SaveSession(session); err != nil {
 			s.logger.Warn("failed to save session", zap.Error(err))
 		}
 		return io.EOF
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"golang.org/x/time/rate"
+)
+
+// Limits bound what each client, identified by its IP or by the subject of its
+// identity when authenticated, can use of the server, zero meaning no limit.
+type Limits struct {
+	// ConcurrentConversations is the number of conversations a client can
+	// have in progress at once.
+	ConcurrentConversations int
+	// ConversationsPerMinute is the number of conversations a client can
+	// start per minute.
+	ConversationsPerMinute int
+	// LookupsPerConversation is the number of lookups (ex: fetching an ABI
+	// from a block explorer) a conversation can make.
+	LookupsPerConversation int
+	// ClientIPHeader, if set, is the header holding the IP of the clients,
+	// ex: `X-Forwarded-For` behind a proxy. The right-most IP, added by the
+	// proxy, is used when it holds several: those before it are sent by the
+	// client and can be spoofed. Otherwise the clients are identified by their
+	// address.
+	ClientIPHeader string
+}
+
+// limitError is the error of a conversation refused or closed for reaching a
+// limit, along with the message telling the user about it.
+type limitError struct {
+	limit   string
+	message string
+}
+
+func (e *limitError) Error() string {
+	return fmt.Sprintf("%s limit reached", e.limit)
+}
+
+// clientLimiter keeps track of the conversations of each client, to enforce
+// the limits on their number.
+type clientLimiter struct {
+	limits Limits
+
+	mu        sync.Mutex
+	clients   map[string]*clientUsage
+	lastSweep time.Time
+}
+
+type clientUsage struct {
+	active int
+	starts *rate.Limiter
+}
+
+func newClientLimiter(limits Limits) *clientLimiter {
+	return &clientLimiter{
+		limits:  limits,
+		clients: map[string]*clientUsage{},
+	}
+}
+
+// clientOf identifies the client of a request, by the subject of its identity
+// when authenticated, by its IP otherwise.
+func (l *clientLimiter) clientOf(identity *codegen.Identity, peerAddr string, header http.Header) string {
+	if identity != nil {
+		return identity.Subject
+	}
+	if l.limits.ClientIPHeader != "" {
+		if values := header.Values(l.limits.ClientIPHeader); len(values) != 0 {
+			// the proxy appends the IP it got the request from, after
+			// whatever the client sent
+			ips := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(ips[len(ips)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(peerAddr)
+	if err != nil {
+		return peerAddr
+	}
+	return host
+}
+
+// acquire counts a new conversation of the client, returning a *limitError if
+// it goes over a limit. Otherwise release must be called once it ends.
+func (l *clientLimiter) acquire(client string) (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	usage := l.clients[client]
+	if usage == nil {
+		usage = &clientUsage{}
+		if perMinute := l.limits.ConversationsPerMinute; perMinute > 0 {
+			usage.starts = rate.NewLimiter(rate.Every(time.Minute/time.Duration(perMinute)), perMinute)
+		}
+		l.clients[client] = usage
+	}
+
+	if max := l.limits.ConcurrentConversations; max > 0 && usage.active >= max {
+		return nil, &limitError{
+			limit:   "concurrent",
+			message: fmt.Sprintf("You reached the limit of %d conversations in progress at once. Please finish one of them, then try again.", max),
+		}
+	}
+	if usage.starts != nil && !usage.starts.AllowN(now, 1) {
+		return nil, &limitError{
+			limit:   "rate",
+			message: fmt.Sprintf("You reached the limit of %d new conversations per minute. Please wait a moment, then try again.", l.limits.ConversationsPerMinute),
+		}
+	}
+
+	usage.active++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			usage.active--
+		})
+	}, nil
+}
+
+// sweep forgets the clients without conversations in progress and whose rate
+// limit is back to full, at most once a minute.
+func (l *clientLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for client, usage := range l.clients {
+		if usage.active == 0 && (usage.starts == nil || usage.starts.TokensAt(now) >= float64(usage.starts.Burst())) {
+			delete(l.clients, client)
+		}
+	}
+}
+
+func lookupLimitError(max int) *limitError {
+	return &limitError{
+		limit:   "lookups",
+		message: fmt.Sprintf("This conversation reached the limit of %d lookups to block explorers. Please start a new conversation, providing the ABIs of your contracts yourself if needed.", max),
+	}
+}
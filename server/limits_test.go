@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientOf(t *testing.T) {
+	limiter := newClientLimiter(Limits{ClientIPHeader: "X-Forwarded-For"})
+
+	tests := []struct {
+		name      string
+		identity  *codegen.Identity
+		forwarded []string
+		expect    string
+	}{
+		{name: "peer address", expect: "10.0.0.1"},
+		{name: "proxied", forwarded: []string{"203.0.113.7"}, expect: "203.0.113.7"},
+		{name: "spoofed by the client", forwarded: []string{"198.51.100.1, 203.0.113.7"}, expect: "203.0.113.7"},
+		{name: "spoofed in another header line", forwarded: []string{"198.51.100.1", "203.0.113.7"}, expect: "203.0.113.7"},
+		{name: "authenticated", identity: &codegen.Identity{Subject: "alice"}, forwarded: []string{"203.0.113.7"}, expect: "alice"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := http.Header{}
+			for _, value := range test.forwarded {
+				header.Add("X-Forwarded-For", value)
+			}
+			assert.Equal(t, test.expect, limiter.clientOf(test.identity, "10.0.0.1:4242", header))
+		})
+	}
+}
+
+func TestSpoofedClientIPSharesLimits(t *testing.T) {
+	limiter := newClientLimiter(Limits{ConcurrentConversations: 1, ClientIPHeader: "X-Forwarded-For"})
+
+	first := http.Header{"X-Forwarded-For": {"198.51.100.1, 203.0.113.7"}}
+	release, err := limiter.acquire(limiter.clientOf(nil, "10.0.0.1:4242", first))
+	assert.NoError(t, err)
+	defer release()
+
+	second := http.Header{"X-Forwarded-For": {"198.51.100.2, 203.0.113.7"}}
+	_, err = limiter.acquire(limiter.clientOf(nil, "10.0.0.1:4243", second))
+	assert.ErrorContains(t, err, "concurrent limit reached")
+}
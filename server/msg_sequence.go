@@ -0,0 +1,69 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"connectrpc.com/connect"
+	codegen "github.com/streamingfast/substreams-codegen"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+// msgSequence numbers the outputs of a conversation and keeps track of the prompt
+// waiting for an answer, so that inputs can be checked against it.
+type msgSequence struct {
+	mu sync.Mutex
+
+	requireFromMsgID bool
+	lastMsgID        uint32
+	pendingPromptID  uint32
+	lastInputMsgID   uint32
+}
+
+// newMsgSequence starts numbering after lastMsgID, which is the last message ID
+// seen by a reconnecting client, or 0 for a new conversation. The client version
+// tells whether inputs must carry a `from_msg_id`.
+func newMsgSequence(lastMsgID uint32, clientVersion uint32) *msgSequence {
+	return &msgSequence{
+		requireFromMsgID: clientVersion >= codegen.ProtocolVersionMsgIDs,
+		lastMsgID:        lastMsgID,
+	}
+}
+
+// stamp assigns the next message ID to the output, links it to the last input
+// received, and records it as the pending prompt if it waits for an answer.
+func (s *msgSequence) stamp(msg *pbconvo.SystemOutput) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastMsgID++
+	msg.MsgId = s.lastMsgID
+	msg.FromMsgId = s.lastInputMsgID
+	if msg.IsPrompt() {
+		s.pendingPromptID = msg.MsgId
+	}
+}
+
+// checkInput verifies that the input answers the pending prompt. Inputs without
+// a `from_msg_id` are only accepted from clients older than ProtocolVersionMsgIDs,
+// which do not track message IDs.
+func (s *msgSequence) checkInput(req *pbconvo.UserInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.FromMsgId == 0 && s.requireFromMsgID {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("received an input without from_msg_id, which is required since protocol version %d", codegen.ProtocolVersionMsgIDs))
+	}
+	if req.FromMsgId != 0 {
+		if s.pendingPromptID == 0 {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("received an answer to message %d, but no prompt is pending", req.FromMsgId))
+		}
+		if req.FromMsgId != s.pendingPromptID {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("received an answer to message %d, but the pending prompt is message %d", req.FromMsgId, s.pendingPromptID))
+		}
+	}
+
+	s.pendingPromptID = 0
+	s.lastInputMsgID = req.MsgId
+	return nil
+}
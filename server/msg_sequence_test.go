@@ -0,0 +1,38 @@
+package server
+
+import (
+	"testing"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientVersion uint32
+		fromMsgID     uint32
+		expectErr     bool
+	}{
+		{name: "answer to the pending prompt", clientVersion: codegen.ProtocolVersionMsgIDs, fromMsgID: 2},
+		{name: "answer to another message", clientVersion: codegen.ProtocolVersionMsgIDs, fromMsgID: 1, expectErr: true},
+		{name: "missing from_msg_id", clientVersion: codegen.ProtocolVersionMsgIDs, expectErr: true},
+		{name: "missing from_msg_id from a legacy client", clientVersion: codegen.ProtocolVersionRichOutput},
+		{name: "answer to another message from a legacy client", clientVersion: codegen.ProtocolVersionRichOutput, fromMsgID: 1, expectErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			seq := newMsgSequence(0, test.clientVersion)
+			seq.stamp(&pbconvo.SystemOutput{Entry: &pbconvo.SystemOutput_Message_{Message: &pbconvo.SystemOutput_Message{Markdown: "hello"}}})
+			seq.stamp(&pbconvo.SystemOutput{Entry: &pbconvo.SystemOutput_TextInput_{TextInput: &pbconvo.SystemOutput_TextInput{Prompt: "name?"}}})
+
+			err := seq.checkInput(&pbconvo.UserInput{FromMsgId: test.fromMsgID, Entry: &pbconvo.UserInput_TextInput_{TextInput: &pbconvo.UserInput_TextInput{Value: "x"}}})
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
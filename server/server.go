@@ -5,12 +5,14 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	dgrpcserver "github.com/streamingfast/dgrpc/server"
 	connectweb "github.com/streamingfast/dgrpc/server/connectrpc"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/shutter"
+	codegen "github.com/streamingfast/substreams-codegen"
 	"github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1/pbconvoconnect"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
@@ -25,19 +27,42 @@ type server struct {
 	corsHostRegexAllow *regexp.Regexp
 	sessionLogger      SessionLogger
 	logger             *zap.Logger
+
+	stateSigner         *codegen.StateSigner
+	unsignedStatePolicy UnsignedStatePolicy
+	localFilesRoot      string
+	commandTimeout      time.Duration
+	limits              Limits
+	limiter             *clientLimiter
+	verifier            Verifier
 }
 
+// New creates the server of the conversations. When verifier is not nil, the
+// clients must authenticate, and the conversations carry their identity.
 func New(
 	httpListenAddr string,
 	corsHostRegexAllow *regexp.Regexp,
 	sessionStore dstore.Store,
+	stateSigner *codegen.StateSigner,
+	unsignedStatePolicy UnsignedStatePolicy,
+	localFilesRoot string,
+	commandTimeout time.Duration,
+	limits Limits,
+	verifier Verifier,
 	logger *zap.Logger,
 ) *server {
 	out := &server{
-		Shutter:            shutter.New(),
-		httpListenAddr:     httpListenAddr,
-		corsHostRegexAllow: corsHostRegexAllow,
-		logger:             logger,
+		Shutter:             shutter.New(),
+		httpListenAddr:      httpListenAddr,
+		corsHostRegexAllow:  corsHostRegexAllow,
+		logger:              logger,
+		stateSigner:         stateSigner,
+		unsignedStatePolicy: unsignedStatePolicy,
+		localFilesRoot:      localFilesRoot,
+		commandTimeout:      commandTimeout,
+		limits:              limits,
+		limiter:             newClientLimiter(limits),
+		verifier:            verifier,
 	}
 	if sessionStore != nil {
 		out.sessionLogger = StoreSessionLogger{store: sessionStore}
@@ -76,6 +101,9 @@ func (s *server) Run() {
 	}
 
 	convoHandlerGetter := func(opts ...connect.HandlerOption) (string, http.Handler) {
+		if s.verifier != nil {
+			opts = append(opts, connect.WithInterceptors(&authInterceptor{verifier: s.verifier}))
+		}
 		return pbconvoconnect.NewConversationServiceHandler(s, opts...)
 	}
 
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+// UnsignedStatePolicy decides what happens to a hydrated state that does not carry
+// a valid signature from this server.
+type UnsignedStatePolicy string
+
+const (
+	// UnsignedStateReject refuses to start the conversation.
+	UnsignedStateReject UnsignedStatePolicy = "reject"
+	// UnsignedStateMark starts the conversation with the state marked as unsigned,
+	// generators then reset the fields they cannot trust: the ones they fetched or
+	// computed (ex: ABIs, initial blocks), along with any field failing validation.
+	UnsignedStateMark UnsignedStatePolicy = "mark"
+)
+
+func ParseUnsignedStatePolicy(in string) (UnsignedStatePolicy, error) {
+	switch policy := UnsignedStatePolicy(in); policy {
+	case UnsignedStateReject, UnsignedStateMark:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid unsigned state policy %q, expected %q or %q", in, UnsignedStateReject, UnsignedStateMark)
+}
+
+// checkHydrate verifies the signature of the hydrated state, if any, and returns
+// whether the conversation must treat it as unsigned. It always returns false
+// when no signing secret is configured.
+func (s *server) checkHydrate(hydrate *pbconvo.UserInput_Hydrate) (unsigned bool, err error) {
+	if hydrate == nil || s.stateSigner == nil {
+		return false, nil
+	}
+
+	if s.stateSigner.Verify(hydrate.SavedState, hydrate.Signature) {
+		return false, nil
+	}
+
+	if s.unsignedStatePolicy == UnsignedStateReject {
+		if len(hydrate.Signature) == 0 {
+			return false, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("the saved state is not signed, start a new conversation or hydrate a state produced by this server"))
+		}
+		return false, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("the saved state signature is invalid, start a new conversation or hydrate a state produced by this server"))
+	}
+	return true, nil
+}
@@ -1,14 +1,15 @@
 package server
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/streamingfast/dstore"
+	codegen "github.com/streamingfast/substreams-codegen"
 	"go.uber.org/zap"
 )
 
@@ -31,41 +32,33 @@ func (s *server) writeError(w http.ResponseWriter, statusCode int, err error) {
 }
 
 type SessionLogger interface {
-	SaveSession(codegen string, events []string, lastState string) error
+	SaveSession(session *codegen.Session) error
 }
 
 type StoreSessionLogger struct {
 	store dstore.Store
 }
 
-func (s StoreSessionLogger) SaveSession(codegen string, events []string, lastState string) error {
-	filename := fmt.Sprintf("session-%s-%s.log", codegen, time.Now().Format(time.RFC3339))
+// SaveSession writes the records of the session as JSON lines, to be queried or
+// replayed with the `replay` command, and next to them their text rendering,
+// for humans.
+func (s StoreSessionLogger) SaveSession(session *codegen.Session) error {
+	basename := fmt.Sprintf("session-%s-%s", session.Generator, time.Now().Format(time.RFC3339))
 
-	var buf bytes.Buffer
-	w := bufio.NewWriter(&buf)
-	r := bufio.NewReader(&buf)
-	go func() {
-		for _, event := range events {
-			_, err := fmt.Fprintln(w, event)
-			if err != nil {
-				fmt.Println("error writing to buffer", err)
-				return
-			}
-		}
-		fmt.Fprintln(w, "\nJSON state:")
-		fmt.Fprintln(w, lastState)
-		w.Flush()
-	}()
-	return s.store.WriteObject(context.TODO(), filename, r)
+	records, err := session.JSONLines()
+	if err != nil {
+		return err
+	}
+	if err := s.store.WriteObject(context.TODO(), basename+".jsonl", bytes.NewReader(records)); err != nil {
+		return err
+	}
+	return s.store.WriteObject(context.TODO(), basename+".log", strings.NewReader(session.Text()))
 }
 
 type PrintSessionLogger struct{}
 
-func (p PrintSessionLogger) SaveSession(codegen string, events []string, lastState string) error {
-	_ = lastState
-	fmt.Println("Session log for codegen", codegen)
-	for _, event := range events {
-		println(event)
-	}
+func (p PrintSessionLogger) SaveSession(session *codegen.Session) error {
+	fmt.Println("Session log for codegen", session.Generator)
+	fmt.Print(session.Text())
 	return nil
 }
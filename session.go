@@ -0,0 +1,585 @@
+package codegen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Directions of the session records.
+const (
+	SessionIn       = "in"       // an input received from the user
+	SessionOut      = "out"      // an output sent to the user
+	SessionInternal = "internal" // something the server did, ex: run a command
+)
+
+// Kinds of the internal session records. The kind of the other records is the
+// entry of their input or output (ex: `text_input`, `list_select`).
+const (
+	SessionKindCommand = "command"
+	SessionKindEnd     = "end"
+)
+
+// Outcomes of a session, on its `end` record.
+const (
+	SessionCompleted = "completed"
+	SessionError     = "error"
+	// SessionAbandoned is the outcome when the client left before the end of
+	// the conversation.
+	SessionAbandoned = "abandoned"
+)
+
+// SessionRecord is one event of a conversation. Sessions are saved as JSON
+// lines, one record per line, so that they can be queried without parsing
+// their text rendering.
+type SessionRecord struct {
+	Time      time.Time `json:"time"`
+	SessionID string    `json:"session_id"`
+	Generator string    `json:"generator"`
+	// Subject and Tenant are those of the identity of the user, when the
+	// server requires authentication.
+	Subject   string `json:"subject,omitempty"`
+	Tenant    string `json:"tenant,omitempty"`
+	Direction string `json:"direction"`
+	Kind      string `json:"kind"`
+	// ActionID is the action ID of the prompt sent, or of the prompt answered
+	// by an input.
+	ActionID string `json:"action_id,omitempty"`
+	// Value is the answer of an input (ex: the text typed, the selected values
+	// separated by commas), or the type of the message returned by a command.
+	Value string `json:"value,omitempty"`
+	// Invalid is the reason why an input was rejected.
+	Invalid string `json:"invalid,omitempty"`
+	// DurationMs is the time taken to answer the prompt for an input, the
+	// duration of a command, or of the whole conversation on the `end` record.
+	DurationMs int64 `json:"duration_ms,omitempty"`
+
+	// Set on the `start` record.
+	Unsigned     bool `json:"unsigned,omitempty"`
+	Reconnecting bool `json:"reconnecting,omitempty"`
+
+	// Set on the `end` record. Errors is the chain of errors the conversation
+	// ended with, from the outermost one to the root cause.
+	Outcome string   `json:"outcome,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+	Stack   string   `json:"stack,omitempty"`
+
+	// The input received or output sent, as is, to replay the session.
+	Input  *pbconvo.UserInput    `json:"-"`
+	Output *pbconvo.SystemOutput `json:"-"`
+}
+
+type sessionRecordJSON SessionRecord
+
+func (r *SessionRecord) MarshalJSON() ([]byte, error) {
+	out := struct {
+		*sessionRecordJSON
+		Input  json.RawMessage `json:"input,omitempty"`
+		Output json.RawMessage `json:"output,omitempty"`
+	}{sessionRecordJSON: (*sessionRecordJSON)(r)}
+
+	var err error
+	if r.Input != nil {
+		if out.Input, err = protojson.Marshal(r.Input); err != nil {
+			return nil, err
+		}
+	}
+	if r.Output != nil {
+		if out.Output, err = protojson.Marshal(r.Output); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(out)
+}
+
+func (r *SessionRecord) UnmarshalJSON(cnt []byte) error {
+	in := struct {
+		*sessionRecordJSON
+		Input  json.RawMessage `json:"input,omitempty"`
+		Output json.RawMessage `json:"output,omitempty"`
+	}{sessionRecordJSON: (*sessionRecordJSON)(r)}
+	if err := json.Unmarshal(cnt, &in); err != nil {
+		return err
+	}
+
+	if in.Input != nil {
+		r.Input = &pbconvo.UserInput{}
+		if err := protojson.Unmarshal(in.Input, r.Input); err != nil {
+			return fmt.Errorf("decoding input: %w", err)
+		}
+	}
+	if in.Output != nil {
+		r.Output = &pbconvo.SystemOutput{}
+		if err := protojson.Unmarshal(in.Output, r.Output); err != nil {
+			return fmt.Errorf("decoding output: %w", err)
+		}
+	}
+	return nil
+}
+
+// Session is the record of a conversation, kept by the server and saved with
+// its text rendering: the `start` input, then every input received, output sent
+// and command run, in order, and the `end` of the conversation with its
+// outcome. It can be replayed with ReplaySession, to reproduce a conversation
+// locally.
+type Session struct {
+	ID        string
+	Generator string
+	// Identity is who the user authenticated as, nil when they are not. It
+	// must be set before the first record.
+	Identity *Identity
+	Records  []*SessionRecord
+
+	lastPrompt *SessionRecord
+	mu         sync.Mutex
+}
+
+func NewSession(generator string) *Session {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return &Session{ID: hex.EncodeToString(id), Generator: generator}
+}
+
+func (s *Session) add(record *SessionRecord) *SessionRecord {
+	record.Time = time.Now()
+	record.SessionID = s.ID
+	record.Generator = s.Generator
+	if s.Identity != nil {
+		record.Subject, record.Tenant = s.Identity.Subject, s.Identity.Tenant
+	}
+	s.Records = append(s.Records, record)
+	return record
+}
+
+// Start records the `start` input of the conversation.
+func (s *Session) Start(input *pbconvo.UserInput, unsigned, reconnecting bool) *SessionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(&SessionRecord{
+		Direction:    SessionIn,
+		Kind:         entryName(input),
+		Unsigned:     unsigned,
+		Reconnecting: reconnecting,
+		Input:        input,
+	})
+}
+
+func (s *Session) AddInput(msg IncomingMessage) *SessionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := &SessionRecord{
+		Direction: SessionIn,
+		Kind:      entryName(msg.Input),
+		Value:     inputValue(msg.Input),
+		Input:     msg.Input,
+	}
+	if invalid, ok := msg.Msg.(MsgInvalidInput); ok {
+		record.Invalid = invalid.Reason
+	}
+	s.add(record)
+	if s.lastPrompt != nil {
+		record.ActionID = s.lastPrompt.ActionID
+		record.DurationMs = record.Time.Sub(s.lastPrompt.Time).Milliseconds()
+	}
+	return record
+}
+
+func (s *Session) AddOutput(output *pbconvo.SystemOutput) *SessionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := s.add(&SessionRecord{
+		Direction: SessionOut,
+		Kind:      entryName(output),
+		ActionID:  output.ActionId,
+		Output:    output,
+	})
+	if output.ActionId != "" {
+		s.lastPrompt = record
+	}
+	return record
+}
+
+// AddCommand records a command run by the loop, with the message it returned.
+// It can be called from any goroutine.
+func (s *Session) AddCommand(result loop.Msg, elapsed time.Duration) *SessionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(&SessionRecord{
+		Direction:  SessionInternal,
+		Kind:       SessionKindCommand,
+		Value:      fmt.Sprintf("%T", result),
+		DurationMs: elapsed.Milliseconds(),
+	})
+}
+
+// End records the end of the conversation, with the error it ended with, if
+// any. abandoned tells whether the client left, the error is then the one of
+// the connection.
+func (s *Session) End(err error, abandoned bool) *SessionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := &SessionRecord{
+		Direction: SessionInternal,
+		Kind:      SessionKindEnd,
+		Outcome:   SessionCompleted,
+	}
+	if err != nil {
+		record.Outcome = SessionError
+		if abandoned {
+			record.Outcome = SessionAbandoned
+		}
+		record.Errors = errorChain(err)
+		var panicErr *loop.PanicError
+		if errors.As(err, &panicErr) {
+			record.Stack = string(panicErr.Stack)
+		}
+	}
+	s.add(record)
+	record.DurationMs = record.Time.Sub(s.Records[0].Time).Milliseconds()
+	return record
+}
+
+// EndRecord returns the `end` record of the session, nil if the conversation
+// hasn't ended.
+func (s *Session) EndRecord() *SessionRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.Records) == 0 || s.Records[len(s.Records)-1].Kind != SessionKindEnd {
+		return nil
+	}
+	return s.Records[len(s.Records)-1]
+}
+
+// State returns the last state sent to the client.
+func (s *Session) State() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.Records) - 1; i >= 0; i-- {
+		if s.Records[i].Output != nil {
+			return s.Records[i].Output.State
+		}
+	}
+	return ""
+}
+
+// JSONLines returns the records of the session, one JSON object per line.
+func (s *Session) JSONLines() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, record := range s.Records {
+		if err := enc.Encode(record); err != nil {
+			return nil, fmt.Errorf("encoding session record: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseSession reads a session saved with JSONLines.
+func ParseSession(cnt []byte) (*Session, error) {
+	s := &Session{}
+	scanner := bufio.NewScanner(bytes.NewReader(cnt))
+	// outputs hold the whole state, and the generated files
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		record := &SessionRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		s.Records = append(s.Records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.Records) != 0 {
+		s.ID = s.Records[0].SessionID
+		s.Generator = s.Records[0].Generator
+		if subject := s.Records[0].Subject; subject != "" {
+			s.Identity = &Identity{Subject: subject, Tenant: s.Records[0].Tenant}
+		}
+	}
+	return s, nil
+}
+
+// Text renders the session as text, for humans, followed by the last state sent.
+func (s *Session) Text() string {
+	out := &strings.Builder{}
+	var previous *SessionRecord
+	for _, record := range s.Records {
+		if record.Direction == SessionOut && previous != nil && previous.Direction == SessionIn && previous.Input.GetStart() == nil {
+			out.WriteString("\n")
+		}
+		fmt.Fprintln(out, s.Render(record))
+		previous = record
+	}
+	fmt.Fprintln(out, "\nJSON state:")
+	fmt.Fprintln(out, s.State())
+	return out.String()
+}
+
+// Render renders a record of the session as text, with the seconds elapsed since
+// the beginning of the session.
+func (s *Session) Render(record *SessionRecord) string {
+	s.mu.Lock()
+	seconds := int(record.Time.Sub(s.Records[0].Time).Seconds())
+	s.mu.Unlock()
+
+	switch {
+	case record.Output != nil:
+		return record.Output.Humanize(seconds)
+	case record.Input.GetStart() != nil:
+		start := record.Input.GetStart()
+		line := fmt.Sprintf("%4d┃ [Start, hydrate: %t, unsigned: %t, reconnecting: %t] %s", seconds, start.Hydrate != nil, record.Unsigned, record.Reconnecting, start.GeneratorId)
+		if record.Subject != "" {
+			line += fmt.Sprintf(" (as %s, tenant: %q)", record.Subject, record.Tenant)
+		}
+		return line
+	case record.Invalid != "":
+		return "\n" + MsgInvalidInput{Reason: record.Invalid}.Humanize(seconds)
+	case record.Input != nil:
+		return "\n" + humanizeInput(record.Input, seconds)
+	case record.Kind == SessionKindCommand:
+		return fmt.Sprintf("%4d [Command returned %s after %dms]", seconds, record.Value, record.DurationMs)
+	case record.Kind == SessionKindEnd && record.Outcome == SessionCompleted:
+		return fmt.Sprintf("COMPLETED IN %d seconds", seconds)
+	case record.Kind == SessionKindEnd:
+		text := fmt.Sprintf("ERROR %q AFTER %d seconds", strings.Join(record.Errors[:min(1, len(record.Errors))], ""), seconds)
+		if record.Outcome == SessionAbandoned {
+			text += " (abandoned)"
+		}
+		if record.Stack != "" {
+			text += "\n" + record.Stack
+		}
+		return text
+	}
+	return fmt.Sprintf("%4d [%s %s]", seconds, record.Direction, record.Kind)
+}
+
+// entryName returns the name of the entry set on an input or output (ex:
+// `text_input`).
+func entryName(msg proto.Message) string {
+	m := msg.ProtoReflect()
+	oneof := m.Descriptor().Oneofs().ByName("entry")
+	if oneof == nil {
+		return ""
+	}
+	field := m.WhichOneof(oneof)
+	if field == nil {
+		return ""
+	}
+	return string(field.Name())
+}
+
+func inputValue(input *pbconvo.UserInput) string {
+	switch entry := input.Entry.(type) {
+	case *pbconvo.UserInput_TextInput_:
+		return entry.TextInput.Value
+	case *pbconvo.UserInput_Selection_:
+		return strings.Join(entry.Selection.SelectedValues(), ",")
+	case *pbconvo.UserInput_Confirmation_:
+		return fmt.Sprintf("%t", entry.Confirmation.Affirmative)
+	case *pbconvo.UserInput_File:
+		return entry.File.Filename
+	case *pbconvo.UserInput_FormResponse_:
+		return strings.TrimPrefix(entry.FormResponse.Humanize(0), "   0 [Form] ")
+	}
+	return ""
+}
+
+// errorChain returns the message of the error and of the errors it wraps.
+func errorChain(err error) (out []string) {
+	for err != nil {
+		out = append(out, err.Error())
+		switch wrapped := err.(type) {
+		case interface{ Unwrap() error }:
+			err = wrapped.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, err := range wrapped.Unwrap() {
+				out = append(out, errorChain(err)...)
+			}
+			return out
+		default:
+			return out
+		}
+	}
+	return out
+}
+
+// SessionDiff is where a replayed conversation first differs from its session:
+// the outputs that followed one of its inputs.
+type SessionDiff struct {
+	// Step is the number of the input after which the outputs differ, 0 for the
+	// `start` input.
+	Step     int
+	Input    string
+	Expected []string
+	Got      []string
+}
+
+func (d *SessionDiff) String() string {
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "Outputs differ after input %d:\n%s\n", d.Step, d.Input)
+	fmt.Fprintf(out, "\nExpected:\n")
+	for _, line := range d.Expected {
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintf(out, "\nGot:\n")
+	for _, line := range d.Got {
+		fmt.Fprintln(out, line)
+	}
+	return out.String()
+}
+
+// ReplaySession runs the conversation of the session again with a Driver,
+// answering its prompts with the recorded inputs, and returns where its outputs
+// first differ from the recorded ones, nil if they never do.
+//
+// Outputs are compared entirely (their state and files included), except for
+// their message IDs and state signature. Lookups really happen, with the given
+// context and command timeout: they can make the outputs differ, if what they
+// return changed since the session.
+func ReplaySession(ctx context.Context, session *Session, commandTimeout time.Duration) (*SessionDiff, error) {
+	if len(session.Records) == 0 || session.Records[0].Input.GetStart() == nil {
+		return nil, fmt.Errorf("session doesn't begin with a start input")
+	}
+	first := session.Records[0]
+	start := first.Input.GetStart()
+
+	driver, err := NewDriver(start.GeneratorId, start.Version)
+	if err != nil {
+		return nil, err
+	}
+	driver.Factory.SetCommandTimeout(commandTimeout)
+	if session.Identity != nil {
+		driver.Factory.SetIdentity(session.Identity)
+		ctx = WithIdentity(ctx, session.Identity)
+	}
+	driver.SetContext(ctx)
+
+	// the error of an abandoned session is the one of the connection
+	var endError string
+	if end := session.EndRecord(); end != nil && end.Outcome == SessionError && len(end.Errors) != 0 {
+		endError = end.Errors[0]
+	}
+
+	steps := sessionSteps(session.Records)
+	seen := 0
+	for i, step := range steps {
+		var err error
+		switch {
+		case i == 0:
+			err = driver.StartWith(start, first.Unsigned)
+		case driver.Prompt() == nil:
+			return &SessionDiff{
+				Step:     i,
+				Input:    humanizeInput(step.input, 0),
+				Expected: humanizeOutputs(step.outputs),
+				Got:      []string{"[The conversation asks nothing more]"},
+			}, nil
+		default:
+			err = driver.AnswerInput(step.input)
+		}
+
+		got := driver.Outputs()[seen:]
+		seen = len(driver.Outputs())
+		expected := humanizeOutputs(step.outputs)
+		if i == len(steps)-1 && endError != "" {
+			expected = append(expected, "ERROR "+endError)
+		}
+		gotLines := humanizeOutputs(got)
+		if err != nil {
+			gotLines = append(gotLines, "ERROR "+err.Error())
+		}
+		if !sameOutputs(step.outputs, got) || !slices.Equal(expected, gotLines) {
+			return &SessionDiff{Step: i, Input: humanizeInput(step.input, 0), Expected: expected, Got: gotLines}, nil
+		}
+	}
+	return nil, nil
+}
+
+type sessionStep struct {
+	input   *pbconvo.UserInput
+	outputs []*pbconvo.SystemOutput
+}
+
+// sessionSteps groups the outputs by the input they follow.
+func sessionSteps(records []*SessionRecord) (out []*sessionStep) {
+	for _, record := range records {
+		switch {
+		case record.Input != nil:
+			out = append(out, &sessionStep{input: record.Input})
+		case record.Output != nil && len(out) != 0:
+			out[len(out)-1].outputs = append(out[len(out)-1].outputs, record.Output)
+		}
+	}
+	return out
+}
+
+func sameOutputs(expected, got []*pbconvo.SystemOutput) bool {
+	if len(expected) != len(got) {
+		return false
+	}
+	for i := range expected {
+		if !proto.Equal(comparableOutput(expected[i]), comparableOutput(got[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// comparableOutput clears what changes between two runs of a conversation.
+func comparableOutput(output *pbconvo.SystemOutput) *pbconvo.SystemOutput {
+	out := proto.Clone(output).(*pbconvo.SystemOutput)
+	out.MsgId = 0
+	out.FromMsgId = 0
+	out.StateSignature = nil
+	return out
+}
+
+func humanizeOutputs(outputs []*pbconvo.SystemOutput) []string {
+	out := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		out = append(out, output.Humanize(0))
+	}
+	return out
+}
+
+func humanizeInput(input *pbconvo.UserInput, seconds int) string {
+	switch entry := input.Entry.(type) {
+	case *pbconvo.UserInput_Start_:
+		return fmt.Sprintf("%4d┃ [Start, hydrate: %t] %s", seconds, entry.Start.Hydrate != nil, entry.Start.GeneratorId)
+	case *pbconvo.UserInput_Back_:
+		return MsgGoBack{}.Humanize(seconds)
+	case *pbconvo.UserInput_TextInput_:
+		return entry.TextInput.Humanize(seconds)
+	case *pbconvo.UserInput_Selection_:
+		return entry.Selection.Humanize(seconds)
+	case *pbconvo.UserInput_Confirmation_:
+		return entry.Confirmation.Humanize(seconds)
+	case *pbconvo.UserInput_DownloadedFiles_:
+		return entry.DownloadedFiles.Humanize(seconds)
+	case *pbconvo.UserInput_File:
+		return entry.File.Humanize(seconds)
+	case *pbconvo.UserInput_FormResponse_:
+		return entry.FormResponse.Humanize(seconds)
+	}
+	return fmt.Sprintf("%4d [%T]", seconds, input.Entry)
+}
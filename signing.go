@@ -0,0 +1,32 @@
+package codegen
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// StateSigner signs the serialized conversation state sent to clients, so that a
+// state coming back through `Hydrate` can be trusted not to have been crafted by
+// hand to skip the server-side validations.
+type StateSigner struct {
+	secret []byte
+}
+
+func NewStateSigner(secret string) *StateSigner {
+	return &StateSigner{secret: []byte(secret)}
+}
+
+// Sign returns the HMAC-SHA256 of the state.
+func (s *StateSigner) Sign(state string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(state))
+	return mac.Sum(nil)
+}
+
+// Verify reports whether signature is a valid signature of state.
+func (s *StateSigner) Verify(state string, signature []byte) bool {
+	if len(signature) == 0 {
+		return false
+	}
+	return hmac.Equal(s.Sign(state), signature)
+}
@@ -34,7 +34,15 @@ func (c *Convo) NextStep() loop.Cmd {
 		return cmd(codegen.AskProjectName{})
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
+}
+
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	return []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+	}
 }
 
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
@@ -46,7 +54,9 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				return loop.Quit(fmt.Errorf(`something went wrong, here's an error message to share with our devs (%s); we've notified them already`, err))
 			}
 
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -59,6 +69,13 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		c.State.Name = msg.Value
 		return c.NextStep()
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
@@ -1,6 +1,7 @@
 package solminimal
 
 import (
+	"context"
 	"embed"
 
 	codegen "github.com/streamingfast/substreams-codegen"
@@ -9,8 +10,8 @@ import (
 //go:embed templates/*
 var templatesFS embed.FS
 
-func (p *Project) Generate() codegen.ReturnGenerate {
-	return codegen.GenerateTemplateTree(p, templatesFS, map[string]string{
+func (p *Project) Generate(ctx context.Context) codegen.ReturnGenerate {
+	return codegen.GenerateTemplateTree(ctx, p, templatesFS, map[string]string{
 		"proto/mydata.proto.gotmpl":     "proto/mydata.proto",
 		"src/pb/mod.rs.gotmpl":          "src/pb/mod.rs",
 		"src/lib.rs.gotmpl":             "src/lib.rs",
@@ -27,6 +27,8 @@ func init() {
 		"Allows you to specified a regex containing the Program IDs used to filter the Solana transactions",
 		codegen.ConversationFactory(New),
 		100,
+		InputFilter{},
+		codegen.InputAskInitialStartBlockType{},
 	)
 }
 
@@ -46,7 +48,24 @@ func (c *Convo) NextStep() loop.Cmd {
 		return cmd(AskFilter{})
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
+}
+
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	return []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+		codegen.InitialBlockField(&p.InitialBlock, &p.InitialBlockSet),
+		{
+			Label: "Program IDs filter",
+			Value: p.Filter,
+			Reset: func() {
+				p.Filter = ""
+				p.FilterContainsAccount = false
+			},
+		},
+	}
 }
 
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
@@ -58,7 +77,9 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				return loop.Quit(fmt.Errorf(`something went wrong, here's an error message to share with our devs (%s); we've notified them already`, err))
 			}
 
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -99,6 +120,13 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		c.State.FilterContainsAccount = strings.Contains(c.State.Filter, "account:")
 		return c.NextStep()
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
@@ -1,6 +1,7 @@
 package soltransactions
 
 import (
+	"context"
 	"testing"
 
 	codegen "github.com/streamingfast/substreams-codegen"
@@ -18,7 +19,7 @@ func TestConvoNextStep(t *testing.T) {
 	assert.Equal(t, codegen.AskProjectName{}, next())
 	p.Name = "my-proj"
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	assert.NoError(t, res.Err)
 	assert.NotEmpty(t, res.ProjectFiles)
 }
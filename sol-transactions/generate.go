@@ -1,6 +1,7 @@
 package soltransactions
 
 import (
+	"context"
 	"embed"
 
 	codegen "github.com/streamingfast/substreams-codegen"
@@ -10,8 +11,8 @@ import (
 var templatesFS embed.FS
 
 // use the output type form the Project to render the templates
-func (p *Project) Generate() codegen.ReturnGenerate {
-	return codegen.GenerateTemplateTree(p, templatesFS, map[string]string{
+func (p *Project) Generate(ctx context.Context) codegen.ReturnGenerate {
+	return codegen.GenerateTemplateTree(ctx, p, templatesFS, map[string]string{
 		"substreams.yaml.gotmpl": "substreams.yaml",
 		"README.md.gotmpl":       "README.md",
 		".gitignore.gotmpl":      ".gitignore",
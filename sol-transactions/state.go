@@ -2,6 +2,9 @@ package soltransactions
 
 import (
 	"strings"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Project struct {
@@ -19,3 +22,7 @@ type Project struct {
 
 func (p *Project) ModuleName() string { return strings.ReplaceAll(p.Name, "-", "_") }
 func (p *Project) KebabName() string  { return strings.ReplaceAll(p.Name, "_", "-") }
+
+func (p *Project) TraceAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{codegen.ChainAttribute.String(p.ChainName)}
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/NethermindEth/juno/core/felt"
 	starknetRPC "github.com/NethermindEth/starknet.go/rpc"
+	codegen "github.com/streamingfast/substreams-codegen"
 )
 
 type Alias struct {
@@ -96,14 +96,12 @@ func (c *Contract) SetAliases() {
 	c.Aliases = aliases
 }
 
-func (c *Contract) fetchABI(config *ChainConfig) (string, error) {
-	client, err := starknetRPC.NewProvider(os.Getenv(config.EndpointEnvVar))
+func (c *Contract) fetchABI(ctx context.Context, config *ChainConfig) (string, error) {
+	client, err := starknetRPC.NewProvider(codegen.Getenv(ctx, config.EndpointEnvVar))
 	if err != nil {
 		return "", fmt.Errorf("creating rpc client: %w", err)
 	}
 
-	ctx := context.Background()
-
 	blockId := starknetRPC.BlockID{
 		Tag: "latest",
 	}
@@ -114,7 +112,9 @@ func (c *Contract) fetchABI(config *ChainConfig) (string, error) {
 		return "", fmt.Errorf("converting address to felt: %w", err)
 	}
 
-	classOutput, err := client.ClassAt(ctx, blockId, addressToFelt)
+	lookupCtx, done := codegen.StartLookup(ctx, config.ID, "class_at")
+	classOutput, err := client.ClassAt(lookupCtx, blockId, addressToFelt)
+	done(err)
 	if err != nil {
 		return "", fmt.Errorf("calling class at for adderss: %s : %w", c.AddressWithoutPrefix(), err)
 	}
@@ -1,10 +1,11 @@
 package starknet_events
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 
@@ -15,6 +16,10 @@ import (
 var QuitInvalidContext = loop.Quit(fmt.Errorf("invalid state context: no current contract"))
 var AbiFilepathPrefix = "file://"
 
+// abiFetchTimeout bounds the lookup of a contract class on the RPC endpoint. The
+// ABI is asked to the user once elapsed.
+const abiFetchTimeout = 30 * time.Second
+
 const EKUBO_POSITIONS_CONTRACT = "0x02e0af29598b407c8716b17f6d2795eca1b471413fa03fb145a5e33722184067"
 
 type Convo struct {
@@ -34,6 +39,14 @@ func init() {
 		"Given a list of contracts and their ABIs, this will build an Starknet substreams that decodes events",
 		codegen.ConversationFactory(New),
 		72,
+		codegen.InputChainName{},
+		InputContractSetup{},
+		InputContractAddress{},
+		InputContractName{},
+		InputContractABI{},
+		InputContractABIUpload{},
+		InputConfirmContractABI{},
+		InputAddContract{},
 	)
 }
 
@@ -68,6 +81,10 @@ func (c *Convo) NextStep() loop.Cmd {
 			return next
 		}
 
+		if contract.Address == "" && contract.Name == "" && c.ClientSupports(codegen.ProtocolVersionForm) {
+			return cmd(AskContractSetup{})
+		}
+
 		if contract.Address == "" {
 			return cmd(AskContractAddress{})
 		}
@@ -98,7 +115,46 @@ func (c *Convo) NextStep() loop.Cmd {
 		return cmd(AskAddContract{})
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
+}
+
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	var chainDisplayName string
+	if conf := p.ChainConfig(); conf != nil {
+		chainDisplayName = conf.DisplayName
+	}
+
+	fields := []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+		codegen.ChainNameField(&p.ChainName, chainDisplayName),
+	}
+	for _, contract := range p.Contracts {
+		prefix := fmt.Sprintf("contract %q", contract.Name)
+		fields = append(fields, codegen.EditableField{
+			Label: prefix + " address",
+			Value: contract.Address,
+			Reset: func() {
+				contract.Address = ""
+				contract.RawABI = nil
+				contract.Abi = nil
+				contract.Aliases = nil
+				contract.emptyABI = false
+				contract.abiFetchedInThisSession = false
+			},
+		}, codegen.EditableField{
+			Label: prefix + " name",
+			Value: contract.Name,
+			Reset: func() { contract.Name = "" },
+		})
+	}
+	fields = append(fields, codegen.EditableField{
+		Label: "Contracts",
+		Value: strings.Join(contractNames(p.Contracts), ", "),
+		Reset: func() { p.ConfirmEnoughContracts = false },
+	})
+	return fields
 }
 
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
@@ -110,7 +166,12 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				return loop.Quit(fmt.Errorf(`something went wrong, here's an error message to share with our devs (%s); we've notified them already`, err))
 			}
 
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if msg.Unsigned {
+				c.State.resetUnvalidatedFields()
+				msgCmd = c.Msg().Message("Ok, I reloaded your state. It was not signed by this server, so the contract ABIs will be fetched or asked again, along with any invalid field.").Cmd()
+			} else if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -130,10 +191,10 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 			return cmd(AskContractABI{})
 		}
 
-		return func() loop.Msg {
-			abi, err := contract.fetchABI(config)
+		return loop.WithContextTimeout(abiFetchTimeout, func(ctx context.Context) loop.Msg {
+			abi, err := contract.fetchABI(ctx, config)
 			return ReturnFetchContractABI{abi: abi, err: err}
-		}
+		})
 
 	case ReturnFetchContractABI:
 		contract := c.contextContract()
@@ -160,9 +221,34 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 			Cmd()
 
 	case AskContractABI:
+		if c.ClientSupports(codegen.ProtocolVersionUpload) {
+			return c.Action(InputContractABIUpload{}).Upload("Please upload the contract ABI", "Upload").
+				Description("Either the JSON ABI file, or the contract class of the contract (JSON)").
+				AcceptedMimeTypes(codegen.ABIMimeTypes...).
+				MaxSize(codegen.MaxABIUploadSize).
+				Cmd()
+		}
+		if !c.LocalFilesEnabled() {
+			return c.Action(InputContractABI{}).TextInput("Please paste the contract ABI", "Submit").
+				Cmd()
+		}
 		return c.Action(InputContractABI{}).TextInput(fmt.Sprintf("Please paste the contract ABI or the full JSON ABI file path starting with %sfullpath/to/Abi.json", AbiFilepathPrefix), "Submit").
 			Cmd()
 
+	case InputContractABIUpload:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+
+		rawMessage, err := codegen.ABIFromFile(msg.Content)
+		if err != nil {
+			return loop.Seq(c.Msg().Messagef("Cannot read the ABI from %q: %s", msg.Filename, err).Cmd(), cmd(AskContractABI{}))
+		}
+
+		contract.RawABI = rawMessage
+		return c.NextStep()
+
 	case InputContractABI:
 		contract := c.contextContract()
 		if contract == nil {
@@ -181,7 +267,7 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		if strings.HasPrefix(msg.Value, AbiFilepathPrefix) {
 			abiPath := strings.TrimPrefix(msg.Value, AbiFilepathPrefix)
 
-			fileBytes, err := os.ReadFile(abiPath)
+			fileBytes, err := c.ReadLocalFile(abiPath)
 			if err != nil {
 				return loop.Seq(c.Msg().Messagef("Cannot read the ABI file %q: %s", abiPath, err).Cmd(), cmd(AskContractABI{}))
 			}
@@ -246,6 +332,49 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		}
 		contract.Name = msg.Value
 		return c.NextStep()
+
+	case MsgInvalidContractName:
+		return c.Msg().
+			Messagef("Invalid contract name: %q", msg.Err).
+			Cmd()
+
+	case AskContractSetup:
+		return c.Action(InputContractSetup{}).Form("Please describe the contract", "Submit").
+			Field("address", codegen.NewFormField().TextInput("Contract address", "").
+				Description(fmt.Sprintf("Format it with 0x prefix and make sure it's a valid Starknet address.\nFor example, the Ekubo Positions contract address: %s", EKUBO_POSITIONS_CONTRACT)).
+				DefaultValue(EKUBO_POSITIONS_CONTRACT).
+				Validation("^0x(0{0,63}[a-fA-F0-9]{1,63}|0{64})$", "Please enter a valid Starknet address")).
+			Field("name", codegen.NewFormField().TextInput("Short name", "").
+				Description("Lowercase and numbers only").
+				Validation(`^([a-z][a-z0-9_]{0,63})$`, "The name should be short, and contain only lowercase characters and numbers, and not start with a number.")).
+			Cmd()
+
+	case InputContractSetup:
+		contract := c.contextContract()
+		if contract == nil {
+			return QuitInvalidContext
+		}
+
+		// Valid fields are kept, the invalid ones are asked again one at a time.
+		var out []loop.Cmd
+		inputAddress := strings.ToLower(msg.TextValue("address"))
+		if err := validateContractAddress(c.State, inputAddress); err != nil {
+			out = append(out, cmd(MsgInvalidContractAddress{err}))
+		} else {
+			contract.handleContractAddress(inputAddress)
+		}
+
+		if err := validateContractName(c.State, msg.TextValue("name")); err != nil {
+			out = append(out, cmd(MsgInvalidContractName{err}))
+		} else {
+			contract.Name = msg.TextValue("name")
+		}
+
+		if len(out) == 0 {
+			return c.NextStep()
+		}
+		return loop.Seq(append(out, c.NextStep())...)
+
 	case RunDecodeContractABI:
 		contract := c.contextContract()
 		if contract == nil {
@@ -269,7 +398,7 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 			return c.NextStep()
 		}
 
-		peekABI := c.Msg().Message(codegen.MarkdownEscape(string(contract.RawABI))).Cmd()
+		peekABI := c.Msg().Code("Contract ABI", "json", string(contract.RawABI)).Cmd()
 
 		informMessage := c.Msg().Message("The ABI is retrieved from the latest block. Changes to the contract's ABI since its deployment are not currently handled.").Cmd()
 		return loop.Seq(peekABI, informMessage, cmd(AskConfirmContractABI{}))
@@ -383,6 +512,13 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		}
 		return c.NextStep()
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
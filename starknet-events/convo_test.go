@@ -1,11 +1,15 @@
 package starknet_events
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	codegen "github.com/streamingfast/substreams-codegen"
 	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConvoNextStep(t *testing.T) {
@@ -18,7 +22,45 @@ func TestConvoNextStep(t *testing.T) {
 	assert.Equal(t, codegen.AskProjectName{}, next())
 	p.Name = "my-proj"
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	assert.NoError(t, res.Err)
 	assert.NotEmpty(t, res.ProjectFiles)
 }
+
+func TestContractABIUpload(t *testing.T) {
+	factory := codegen.NewMsgWrapFactory(nil)
+	factory.SetClientVersion(codegen.ProtocolVersionUpload)
+	conv := New()
+	conv.SetFactory(factory)
+	p := conv.(*Convo).State
+	p.Name = "my-proj"
+	p.ChainName = "starknet-mainnet"
+	p.currentContractIdx = 0
+	p.Contracts = append(p.Contracts, &Contract{Address: "0x04718f5a0fc34cc1af16a1cdee98ffb20c31f5cd61d6ab07201858f4287c938d"})
+
+	require.NotNil(t, conv.Update(AskContractABI{})().(*pbconvo.SystemOutput).GetUpload())
+
+	// Sierra contract classes hold the ABI serialized as a string
+	abi := `[{"type":"event","name":"Transfer","kind":"struct","members":[]}]`
+	contractClass := fmt.Sprintf(`{"sierra_program":[],"abi":%q}`, abi)
+	conv.Update(InputContractABIUpload{pbconvo.UserInput_Upload{Filename: "token.contract_class.json", Content: []byte(contractClass)}})
+	assert.JSONEq(t, abi, string(p.Contracts[0].RawABI))
+}
+
+func TestCommandPanic(t *testing.T) {
+	factory := codegen.NewMsgWrapFactory(nil)
+	var delivered *loop.PanicError
+	factory.SetupLoop(func(msg loop.Msg) loop.Cmd {
+		if msg, ok := msg.(loop.PanicMsg); ok {
+			delivered = msg.Err
+		}
+		return nil
+	})
+
+	err := factory.Run(context.Background(), CmdDecodeABI(&Contract{RawABI: []byte("not an abi")}))
+	var panicErr *loop.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Same(t, delivered, panicErr)
+	assert.Equal(t, "decoding contract abi", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "CmdDecodeABI")
+}
@@ -1,6 +1,7 @@
 package starknet_events
 
 import (
+	"context"
 	"embed"
 	"fmt"
 
@@ -10,8 +11,8 @@ import (
 //go:embed templates/*
 var templatesFS embed.FS
 
-func (p *Project) Generate() codegen.ReturnGenerate {
-	res := codegen.GenerateTemplateTree(p, templatesFS, map[string]string{
+func (p *Project) Generate(ctx context.Context) codegen.ReturnGenerate {
+	res := codegen.GenerateTemplateTree(ctx, p, templatesFS, map[string]string{
 		"proto/events.proto.gotmpl":     "proto/events.proto",
 		"src/abi/mod.rs.gotmpl":         "src/abi/mod.rs",
 		"src/lib.rs.gotmpl":             "src/lib.rs",
@@ -2,8 +2,13 @@ package starknet_events
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Project struct {
@@ -17,9 +22,59 @@ type Project struct {
 	projectFiles           map[string][]byte
 }
 
+// resetUnvalidatedFields clears what a state not signed by this server holds
+// without having been validated by it: the fields failing their validation are
+// asked again, and the ABIs, looked up on explorers, are fetched or asked again.
+func (p *Project) resetUnvalidatedFields() {
+	if !codegen.ValidProjectName(p.Name) {
+		p.Name = ""
+	}
+	for _, contract := range p.Contracts {
+		if !contractAddressRegexp.MatchString(contract.Address) {
+			contract.Address = ""
+		}
+		if !contractNameRegexp.MatchString(contract.Name) {
+			contract.Name = ""
+		}
+		contract.RawABI = nil
+		contract.Aliases = nil
+	}
+}
+
+// Clone deep-copies the project, for going back to restore it along with what is
+// not saved, like the ABIs fetched in this session.
+func (p *Project) Clone() *Project {
+	out := *p
+	out.Contracts = make([]*Contract, len(p.Contracts))
+	for i, contract := range p.Contracts {
+		clone := *contract
+		if contract.InitialBlock != nil {
+			initialBlock := *contract.InitialBlock
+			clone.InitialBlock = &initialBlock
+		}
+		if contract.Aliases != nil {
+			clone.Aliases = make([]*Alias, len(contract.Aliases))
+			for j, alias := range contract.Aliases {
+				clone.Aliases[j] = NewAlias(alias.OldName, alias.NewName)
+			}
+		}
+		clone.RawABI = slices.Clone(contract.RawABI)
+		out.Contracts[i] = &clone
+	}
+	out.projectFiles = maps.Clone(p.projectFiles)
+	return &out
+}
+
 func (p *Project) ModuleName() string { return strings.ReplaceAll(p.Name, "-", "_") }
 func (p *Project) KebabName() string  { return strings.ReplaceAll(p.Name, "_", "-") }
 
+func (p *Project) TraceAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		codegen.ChainAttribute.String(p.ChainName),
+		codegen.ContractCountAttribute.Int(len(p.Contracts)),
+	}
+}
+
 func (p *Project) ChainConfig() *ChainConfig          { return ChainConfigByID[p.ChainName] }
 func (p *Project) ChainNetwork() string               { return ChainConfigByID[p.ChainName].Network }
 func (p *Project) IsValidChainName(input string) bool { return ChainConfigByID[input] != nil }
@@ -51,8 +106,15 @@ func isValidChainName(input string) bool {
 	return ChainConfigByID[input] != nil
 }
 
+var (
+	// contractAddressRegexp and contractNameRegexp validate the addresses and
+	// names entered for the contracts.
+	contractAddressRegexp = regexp.MustCompile("^0x(0{0,63}[a-fA-F0-9]{1,63}|0{64})$")
+	contractNameRegexp    = regexp.MustCompile(`^([a-z][a-z0-9_]{0,63})$`)
+)
+
 func validateContractName(p *Project, name string) error {
-	if !regexp.MustCompile(`^([a-z][a-z0-9_]{0,63})$`).MatchString(name) {
+	if !contractNameRegexp.MatchString(name) {
 		return fmt.Errorf("contract name %s is invalid, it must match the regex ^([a-z][a-z0-9_]{0,63})$", name)
 	}
 
@@ -16,6 +16,11 @@ type InputChainName struct{ pbconvo.UserInput_Selection }
 // type StartFirstContract struct{} // Start asking for contract inputs
 type MsgContractSwitch struct{}
 
+// AskContractSetup asks for the address and name of a new contract at once,
+// for clients supporting forms.
+type AskContractSetup struct{}
+type InputContractSetup struct{ pbconvo.UserInput_FormResponse }
+
 type AskContractAddress struct{}
 type AskEventAddress struct{}
 type InputEventAddress struct{ pbconvo.UserInput_TextInput }
@@ -48,6 +53,7 @@ type ReturnFetchContractABI struct {
 type StartFirstContract struct{} // Start asking for contract inputs
 type AskContractABI struct{}
 type InputContractABI struct{ pbconvo.UserInput_TextInput }
+type InputContractABIUpload struct{ pbconvo.UserInput_Upload }
 
 type RunDecodeContractABI struct{}
 type ReturnRunDecodeContractABI struct {
@@ -1,6 +1,7 @@
 package starknetminimal
 
 import (
+	"context"
 	"testing"
 
 	codegen "github.com/streamingfast/substreams-codegen"
@@ -21,7 +22,7 @@ func TestConvoNextStep(t *testing.T) {
 	assert.Equal(t, codegen.AskChainName{}, next())
 	p.ChainName = "starknet-mainnet"
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	assert.NoError(t, res.Err)
 	assert.NotEmpty(t, res.ProjectFiles)
 }
@@ -2,6 +2,7 @@ package codegen
 
 import (
 	"bytes"
+	"context"
 	"embed"
 	"fmt"
 	"io/fs"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/golang-cz/textcase"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var templateFuncs = template.FuncMap{
@@ -34,9 +37,18 @@ type GenerateConfig struct {
 }
 
 // GenerateTemplateTree will read from both the given templateFS and the commonTemplate folder for files prefixed with 'common-templates/'
-func GenerateTemplateTree(projectData any, templatesFS embed.FS, templateFiles map[string]string) ReturnGenerate {
+// The rendering is traced as a child span of ctx.
+func GenerateTemplateTree(ctx context.Context, projectData any, templatesFS embed.FS, templateFiles map[string]string) ReturnGenerate {
+	_, span := otelTracer().Start(ctx, "GenerateTemplateTree", trace.WithAttributes(
+		TemplateCountAttribute.Int(len(templateFiles)),
+	))
+	defer span.End()
+	span.SetAttributes(StateAttributes(projectData)...)
+
 	projFiles, err := generateTemplateTree(projectData, templatesFS, templateFiles)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return ReturnGenerate{Err: err}
 	}
 	return ReturnGenerate{ProjectFiles: projFiles}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzz(t *testing.T) {
+	require.NoError(t, codegen.Fuzz(codegen.FuzzOptions{Answers: fuzzAnswers(t)}))
+}
+
+// TestActionsCatalog checks that the prompts asked by the generators are all
+// listed in the catalog returned by Discover, which is maintained by hand.
+func TestActionsCatalog(t *testing.T) {
+	asked := map[string]map[string]bool{}
+	err := codegen.Fuzz(codegen.FuzzOptions{
+		Answers: fuzzAnswers(t),
+		OnPrompt: func(generator string, prompt *pbconvo.SystemOutput) {
+			if asked[generator] == nil {
+				asked[generator] = map[string]bool{}
+			}
+			asked[generator][prompt.ActionId] = true
+		},
+	})
+	require.NoError(t, err)
+
+	for generator, handler := range codegen.Registry {
+		require.NotEmpty(t, asked[generator], generator)
+		catalog := actionIDs(handler)
+		for actionID := range asked[generator] {
+			assert.Contains(t, catalog, actionID, "prompt of generator %q missing from the inputs given to RegisterConversation", generator)
+		}
+	}
+}
+
+// fuzzAnswers returns the ABIs to answer the ABI prompts with, which cannot be
+// made up.
+func fuzzAnswers(t *testing.T) map[string][]string {
+	abis := map[string][]string{
+		"evm-events-calls":     recordedABIs(t, "../evm-events-calls/testdata/bayc_contract.abi.json", "../evm-events-calls/testdata/*.json"),
+		"starknet-events-beta": recordedABIs(t, "starknet-events/generator.json"),
+	}
+
+	answers := map[string][]string{}
+	for generator, generatorABIs := range abis {
+		require.NotEmpty(t, generatorABIs, generator)
+		actions := actionIDs(codegen.Registry[generator])
+		for _, action := range []string{"contract_abi", "contract_abi_upload", "dynamic_contract_abi", "dynamic_contract_abi_upload"} {
+			if id := generator + "." + action; slices.Contains(actions, id) {
+				answers[id] = generatorABIs
+			}
+		}
+	}
+	return answers
+}
+
+// recordedABIs returns the ABIs of the files: either ABI files, or saved states
+// (possibly in a generator.json file) from which the ABIs of the contracts are
+// taken.
+func recordedABIs(t *testing.T, patterns ...string) []string {
+	var out []string
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		require.NoError(t, err)
+		for _, file := range files {
+			cnt, err := os.ReadFile(file)
+			require.NoError(t, err)
+			if strings.HasSuffix(file, ".abi.json") {
+				out = append(out, string(cnt))
+				continue
+			}
+
+			var state struct {
+				Contracts []struct {
+					RawABI json.RawMessage `json:"rawAbi"`
+				} `json:"contracts"`
+			}
+			generatorFile := &codegen.GeneratorFile{}
+			if json.Unmarshal(cnt, generatorFile) == nil && len(generatorFile.State) != 0 {
+				cnt = generatorFile.State
+			}
+			if json.Unmarshal(cnt, &state) != nil {
+				continue
+			}
+			for _, contract := range state.Contracts {
+				if len(contract.RawABI) != 0 {
+					out = append(out, string(contract.RawABI))
+				}
+			}
+		}
+	}
+	return out
+}
+
+func actionIDs(handler *codegen.ConversationHandler) []string {
+	var out []string
+	for _, action := range handler.Actions() {
+		out = append(out, action.Id)
+	}
+	return out
+}
@@ -97,6 +97,12 @@ func TestIntegration(t *testing.T) {
 				":9000",
 				cors,
 				sessionStore,
+				nil,
+				server.UnsignedStateMark,
+				"",
+				time.Minute,
+				server.Limits{},
+				nil,
 				zlog)
 			server.Run()
 		}()
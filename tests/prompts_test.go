@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	codegen "github.com/streamingfast/substreams-codegen"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/streamingfast/substreams-codegen/evm-events-calls"
+	_ "github.com/streamingfast/substreams-codegen/evm-minimal"
+	_ "github.com/streamingfast/substreams-codegen/injective-events"
+	_ "github.com/streamingfast/substreams-codegen/injective-minimal"
+	_ "github.com/streamingfast/substreams-codegen/sol-minimal"
+	_ "github.com/streamingfast/substreams-codegen/sol-transactions"
+	_ "github.com/streamingfast/substreams-codegen/starknet-events"
+	_ "github.com/streamingfast/substreams-codegen/starknet-minimal"
+	_ "github.com/streamingfast/substreams-codegen/vara-extrinsics"
+	_ "github.com/streamingfast/substreams-codegen/vara-minimal"
+)
+
+func TestPromptPairings(t *testing.T) {
+	files, err := filepath.Glob("*/generator.json")
+	require.NoError(t, err)
+
+	var seeds []*codegen.GeneratorFile
+	for _, file := range files {
+		cnt, err := os.ReadFile(file)
+		require.NoError(t, err)
+		seed := &codegen.GeneratorFile{}
+		require.NoError(t, json.Unmarshal(cnt, seed))
+		seeds = append(seeds, seed)
+	}
+
+	require.NoError(t, codegen.CheckPromptPairings(seeds...))
+}
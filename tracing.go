@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streamingfast/substreams-codegen/loop"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelTracer returns the tracer starting the spans of the conversations. It is
+// taken from the global tracer provider on each call, as the provider is set up
+// from `SF_TRACING` by the server, or replaced by tests.
+func otelTracer() trace.Tracer {
+	return otel.Tracer("github.com/streamingfast/substreams-codegen")
+}
+
+// Attributes of the spans of the conversations.
+const (
+	GeneratorAttribute     = attribute.Key("codegen.generator")
+	ChainAttribute         = attribute.Key("codegen.chain")
+	ContractCountAttribute = attribute.Key("codegen.contract_count")
+	MessageAttribute       = attribute.Key("codegen.message")
+	LookupAttribute        = attribute.Key("codegen.lookup")
+	TemplateCountAttribute = attribute.Key("codegen.template_count")
+)
+
+// TraceAttributer is implemented by the states adding attributes, like their
+// chain and number of contracts, to the spans of their conversation.
+type TraceAttributer interface {
+	TraceAttributes() []attribute.KeyValue
+}
+
+// StateAttributes returns the attributes of state, if it is a TraceAttributer.
+func StateAttributes(state any) []attribute.KeyValue {
+	if attributer, ok := state.(TraceAttributer); ok {
+		return attributer.TraceAttributes()
+	}
+	return nil
+}
+
+// TracedUpdate runs the Update of the conversation within a span carrying the
+// type of msg, the generator and the attributes of the state once updated.
+func TracedUpdate(ctx context.Context, conv Converser, generatorID string, msg loop.Msg) loop.Cmd {
+	_, span := otelTracer().Start(ctx, "Update", trace.WithAttributes(
+		GeneratorAttribute.String(generatorID),
+		MessageAttribute.String(fmt.Sprintf("%T", msg)),
+	))
+	defer span.End()
+
+	cmd := conv.Update(msg)
+	span.SetAttributes(StateAttributes(conv.GetState())...)
+	return cmd
+}
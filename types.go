@@ -1,6 +1,8 @@
 package codegen
 
 import (
+	"fmt"
+
 	"github.com/streamingfast/substreams-codegen/loop"
 	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
 )
@@ -12,7 +14,9 @@ type AskChainName struct{}
 type MsgInvalidChainName struct{}
 type InputChainName struct{ pbconvo.UserInput_Selection }
 
-type InputSourceDownloaded struct{ pbconvo.UserInput_Confirmation }
+type InputSourceDownloaded struct {
+	pbconvo.UserInput_DownloadedFiles
+}
 type PackageDownloaded struct{ pbconvo.UserInput_Confirmation }
 
 type AskConfirmCompile struct{}
@@ -33,6 +37,13 @@ func InputAskInitialStartBlockTypeValidation() string {
 	return "The start block cannot be empty and must be a number"
 }
 
+// MsgGoBack is sent when the user asks to undo their last answer.
+type MsgGoBack struct{}
+
+func (MsgGoBack) Humanize(seconds int) string {
+	return fmt.Sprintf("%4d [Back]", seconds)
+}
+
 type RunGenerate struct{}
 
 type ReturnGenerate struct {
@@ -0,0 +1,81 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+)
+
+// MaxABIUploadSize is the largest ABI file accepted in uploads. Build artifacts
+// embed the bytecode along with the ABI, so they can weigh a few megabytes.
+const MaxABIUploadSize = 16 * 1024 * 1024
+
+// ABIMimeTypes are the accepted types of uploaded ABI files.
+var ABIMimeTypes = []string{"application/json"}
+
+// UploadMimeType returns the type of the uploaded file, falling back to the one
+// inferred from its extension when the client didn't send any.
+func UploadMimeType(upload *pbconvo.UserInput_Upload) string {
+	if upload.MimeType != "" {
+		return upload.MimeType
+	}
+	return mime.TypeByExtension(filepath.Ext(upload.Filename))
+}
+
+func mimeTypeAccepted(mimeType string, accepted []string) bool {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+	for _, acceptedType := range accepted {
+		if prefix, found := strings.CutSuffix(acceptedType, "/*"); found {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if mediaType == acceptedType {
+			return true
+		}
+	}
+	return false
+}
+
+// ABIFromFile extracts the JSON ABI out of an ABI file, which is either the ABI
+// itself (a JSON array), or a build artifact holding it in its `abi` field:
+// Hardhat and Foundry artifacts hold the array itself, while Starknet (Sierra)
+// contract classes hold it serialized as a string.
+func ABIFromFile(content []byte) (json.RawMessage, error) {
+	var value any
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	switch value.(type) {
+	case []any:
+		return json.RawMessage(content), nil
+	case map[string]any:
+	default:
+		return nil, fmt.Errorf("expected a JSON ABI array, or a build artifact with an `abi` field")
+	}
+
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if err := json.Unmarshal(content, &artifact); err != nil {
+		return nil, fmt.Errorf("decoding build artifact: %w", err)
+	}
+	if len(artifact.ABI) == 0 {
+		return nil, fmt.Errorf("build artifact has no `abi` field")
+	}
+
+	var serialized string
+	if err := json.Unmarshal(artifact.ABI, &serialized); err == nil {
+		return ABIFromFile([]byte(serialized))
+	}
+	return ABIFromFile(artifact.ABI)
+}
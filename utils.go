@@ -16,6 +16,34 @@ func Cmd(msg any) loop.Cmd {
 	}
 }
 
+// WriteProjectFiles writes the generated project files under the given directory,
+// creating any missing sub-directories. The file names can come from a remote
+// server: nothing is written if any of them is not a local path, like an
+// absolute one or one going up with `..`.
+func WriteProjectFiles(outputDir string, files map[string][]byte) error {
+	for relativeFile := range files {
+		if !filepath.IsLocal(filepath.FromSlash(relativeFile)) {
+			return fmt.Errorf("refusing to write %q outside of %q", relativeFile, outputDir)
+		}
+	}
+
+	for relativeFile, content := range files {
+		fullFilepath := filepath.Join(outputDir, filepath.FromSlash(relativeFile))
+		if err := os.MkdirAll(filepath.Dir(fullFilepath), 0755); err != nil {
+			return fmt.Errorf("creating directory for %q: %w", relativeFile, err)
+		}
+
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(fullFilepath, ".sh") {
+			mode = 0755
+		}
+		if err := os.WriteFile(fullFilepath, content, mode); err != nil {
+			return fmt.Errorf("writing %q: %w", relativeFile, err)
+		}
+	}
+	return nil
+}
+
 func ZipFiles(files map[string][]byte) ([]byte, error) {
 	tempDir, err := os.MkdirTemp(os.TempDir(), "zipper")
 	if err != nil {
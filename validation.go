@@ -0,0 +1,143 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/streamingfast/substreams-codegen/loop"
+	pbconvo "github.com/streamingfast/substreams-codegen/pb/sf/codegen/conversation/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// MsgInvalidInput replaces the typed input when the answer doesn't satisfy the
+// constraints of the prompt it answers (validation regexp, allowed values). It
+// never reaches `Update()`: the prompt is asked again with CmdInvalidInput.
+type MsgInvalidInput struct {
+	Reason string
+	Prompt *pbconvo.SystemOutput
+}
+
+func (m MsgInvalidInput) Humanize(seconds int) string {
+	return fmt.Sprintf("%4d [Invalid input: %s]", seconds, m.Reason)
+}
+
+// CmdInvalidInput tells the user why their answer was rejected, and asks the
+// same prompt again.
+func CmdInvalidInput(factory *MsgWrapFactory, msg MsgInvalidInput) loop.Cmd {
+	errMsg := factory.NewMsg(nil).Message(msg.Reason).Style("error")
+	errMsg.Msg.State = msg.Prompt.State
+	errMsg.Msg.StateSignature = msg.Prompt.StateSignature
+
+	return loop.Seq(
+		errMsg.Cmd(),
+		func() loop.Msg { return proto.Clone(msg.Prompt).(*pbconvo.SystemOutput) },
+	)
+}
+
+// checkInput verifies the input against the constraints sent to the client with
+// the prompt. It returns the reason to show to the user when the input is
+// rejected, or an error if the prompt itself is broken.
+func checkInput(prompt *pbconvo.SystemOutput, req *pbconvo.UserInput) (reason string, err error) {
+	if prompt == nil {
+		return "", nil
+	}
+
+	switch entry := prompt.Entry.(type) {
+	case *pbconvo.SystemOutput_TextInput_:
+		input := req.GetTextInput()
+		if input == nil {
+			return fmt.Sprintf("Expected a text answer, got %s", inputKind(req)), nil
+		}
+		if entry.TextInput.ValidationRegexp == "" {
+			return "", nil
+		}
+		re, err := regexp.Compile(entry.TextInput.ValidationRegexp)
+		if err != nil {
+			return "", fmt.Errorf("invalid validation regexp %q of prompt %q: %w", entry.TextInput.ValidationRegexp, entry.TextInput.Prompt, err)
+		}
+		if !re.MatchString(input.Value) {
+			if entry.TextInput.ValidationErrorMessage != "" {
+				return entry.TextInput.ValidationErrorMessage, nil
+			}
+			return fmt.Sprintf("The value %q is not valid, it must match %s", input.Value, entry.TextInput.ValidationRegexp), nil
+		}
+
+	case *pbconvo.SystemOutput_ListSelect_:
+		input := req.GetSelection()
+		if input == nil {
+			return fmt.Sprintf("Expected a selection, got %s", inputKind(req)), nil
+		}
+		if !entry.ListSelect.SelectMany && len(input.Values) > 1 {
+			return "Only one choice can be selected", nil
+		}
+		values := entry.ListSelect.Values
+		if len(values) == 0 {
+			return "", nil
+		}
+		selected := input.SelectedValues()
+		if !entry.ListSelect.SelectMany && len(selected) == 0 {
+			return fmt.Sprintf("%q is not one of the choices: %s", input.Value, strings.Join(values, ", ")), nil
+		}
+		for _, value := range selected {
+			if !slices.Contains(values, value) {
+				return fmt.Sprintf("%q is not one of the choices: %s", value, strings.Join(values, ", ")), nil
+			}
+		}
+
+	case *pbconvo.SystemOutput_Confirm_:
+		if req.GetConfirmation() == nil {
+			return fmt.Sprintf("Expected a confirmation, got %s", inputKind(req)), nil
+		}
+
+	case *pbconvo.SystemOutput_Upload_:
+		input := req.GetFile()
+		if input == nil {
+			return fmt.Sprintf("Expected a file upload, got %s", inputKind(req)), nil
+		}
+		if maxSize := entry.Upload.MaxSize; maxSize != 0 && uint64(len(input.Content)) > maxSize {
+			return fmt.Sprintf("The file %q is %d bytes, it cannot be larger than %d bytes", input.Filename, len(input.Content), maxSize), nil
+		}
+		if accepted := entry.Upload.AcceptedMimeTypes; len(accepted) != 0 && !mimeTypeAccepted(UploadMimeType(input), accepted) {
+			return fmt.Sprintf("The file %q is of type %q, expected one of: %s", input.Filename, UploadMimeType(input), strings.Join(accepted, ", ")), nil
+		}
+
+	case *pbconvo.SystemOutput_Form_:
+		input := req.GetFormResponse()
+		if input == nil {
+			return fmt.Sprintf("Expected a form response, got %s", inputKind(req)), nil
+		}
+		for _, field := range entry.Form.Fields {
+			answer := input.Field(field.Name)
+			if answer == nil {
+				return fmt.Sprintf("%s: missing answer", field.Label()), nil
+			}
+			reason, err := checkInput(field.AsPrompt(), answer.AsInput())
+			if err != nil {
+				return "", fmt.Errorf("form field %q: %w", field.Name, err)
+			}
+			if reason != "" {
+				return fmt.Sprintf("%s: %s", field.Label(), reason), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func inputKind(req *pbconvo.UserInput) string {
+	switch req.Entry.(type) {
+	case *pbconvo.UserInput_TextInput_:
+		return "a text answer"
+	case *pbconvo.UserInput_Selection_:
+		return "a selection"
+	case *pbconvo.UserInput_Confirmation_:
+		return "a confirmation"
+	case *pbconvo.UserInput_File:
+		return "a file upload"
+	case *pbconvo.UserInput_FormResponse_:
+		return "a form response"
+	}
+	return fmt.Sprintf("%T", req.Entry)
+}
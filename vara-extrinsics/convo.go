@@ -25,6 +25,9 @@ func init() {
 		"Allows you to specified a regex containing the Extrinsics used to filter Vara transactions",
 		codegen.ConversationFactory(New),
 		40,
+		codegen.InputChainName{},
+		InputExtrinsicId{},
+		codegen.InputAskInitialStartBlockType{},
 	)
 }
 
@@ -50,7 +53,27 @@ func (c *Convo) NextStep() loop.Cmd {
 		return cmd(AskExtrinsicId{})
 	}
 
-	return cmd(codegen.RunGenerate{})
+	return c.CmdReviewOrGenerate()
+}
+
+// EditableFields lists the fields shown in the review step, before generation.
+func (c *Convo) EditableFields() []codegen.EditableField {
+	p := c.State
+	var chainDisplayName string
+	if conf := ChainConfigByID[p.ChainName]; conf != nil {
+		chainDisplayName = conf.DisplayName
+	}
+
+	return []codegen.EditableField{
+		codegen.ProjectNameField(&p.Name),
+		codegen.ChainNameField(&p.ChainName, chainDisplayName),
+		codegen.InitialBlockField(&p.InitialBlock, &p.InitialBlockSet),
+		{
+			Label: "Extrinsic ID",
+			Value: p.ExtrinsicId,
+			Reset: func() { p.ExtrinsicId = "" },
+		},
+	}
 }
 
 func (c *Convo) Update(msg loop.Msg) loop.Cmd {
@@ -62,7 +85,9 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 				return loop.Quit(fmt.Errorf(`something went wrong, here's an error message to share with our devs (%s); we've notified them already`, err))
 			}
 
-			msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			if !msg.Reconnecting() {
+				msgCmd = c.Msg().Message("Ok, I reloaded your state.").Cmd()
+			}
 		} else {
 			msgCmd = c.Msg().Message("Ok, let's start a new package.").Cmd()
 		}
@@ -128,6 +153,13 @@ func (c *Convo) Update(msg loop.Msg) loop.Cmd {
 		c.State.ExtrinsicId = msg.Value
 		return c.NextStep()
 
+	case codegen.AskReview:
+		return c.CmdAskReview(c.EditableFields())
+
+	case codegen.InputReview:
+		c.ApplyReview(msg.Value, c.EditableFields())
+		return c.NextStep()
+
 	case codegen.RunGenerate:
 		return c.CmdGenerate(c.State.Generate)
 
@@ -1,6 +1,7 @@
 package varaextrinsics
 
 import (
+	"context"
 	"testing"
 
 	codegen "github.com/streamingfast/substreams-codegen"
@@ -19,7 +20,7 @@ func TestConvoNextStep(t *testing.T) {
 	p.Name = "my-proj"
 	p.ChainName = "vara-mainnet"
 
-	res := p.Generate()
+	res := p.Generate(context.Background())
 	assert.NoError(t, res.Err)
 	assert.NotEmpty(t, res.ProjectFiles)
 }